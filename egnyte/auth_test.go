@@ -26,6 +26,9 @@ func init() {
 	// we unmarshal our byteArray which contains our
 	// jsonFile's content into 'users' which we defined above
 	json.Unmarshal(byteValue, &Config)
+	if Config == nil {
+		Config = map[string]string{}
+	}
 	/* load test data */
 	Config["RootPath"] = "/Shared/test/"
 
@@ -0,0 +1,93 @@
+package egnyte
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Egnyte Bookmark APIs
+
+// Bookmark bookmarks the folder for the authenticated user. Use an object of
+// a client returned by Client.ActAs to bookmark it for another user
+func (o *Object) Bookmark(ctx context.Context) (*Bookmark, error) {
+	if err := o.checkFolder(ctx); err != nil {
+		return nil, err
+	}
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   URI_BOOKMARKS,
+	}
+	req := &bookmarkRequest{
+		Path: o.Path,
+	}
+	var bookmark *Bookmark
+	_, err := o.Client.doRequest(ctx, opts, req, &bookmark)
+	if err != nil {
+		return nil, err
+	}
+	if bookmark.Path == "" {
+		bookmark.Path = o.Path
+	}
+	if bookmark.FolderID == "" {
+		bookmark.FolderID = o.FolderID
+	}
+	bookmark.Folder = o
+	return bookmark, nil
+}
+
+// ListBookmarksPage lists a page of at most count bookmarks of the
+// authenticated user starting at offset and returns them with the total
+// number of bookmarks
+func (c *Client) ListBookmarksPage(ctx context.Context, offset, count int) ([]*Bookmark, int, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("count", strconv.Itoa(count))
+	opts := &requestOptions{
+		Method:     "GET",
+		Path:       URI_BOOKMARKS,
+		Parameters: params,
+	}
+	var bookmarksResp *listBookmarksResponse
+	_, err := c.doRequest(ctx, opts, nil, &bookmarksResp)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, bookmark := range bookmarksResp.Bookmarks {
+		bookmark.Folder = c.Object(bookmark.Path)
+		bookmark.Folder.IsFolder = true
+		bookmark.Folder.FolderID = bookmark.FolderID
+	}
+	return bookmarksResp.Bookmarks, bookmarksResp.TotalCount, nil
+}
+
+// ListBookmarks lists the bookmarks of the authenticated user
+// Returns all bookmarks by iterating through all available pages
+func (c *Client) ListBookmarks(ctx context.Context) ([]*Bookmark, error) {
+	var bookmarks []*Bookmark
+	offset := 0
+	itemsPerPage := 100
+	for {
+		page, total, err := c.ListBookmarksPage(ctx, offset, itemsPerPage)
+		if err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, page...)
+		offset += len(page)
+		if len(page) == 0 || offset >= total {
+			break
+		}
+	}
+	return bookmarks, nil
+}
+
+// DeleteBookmark deletes the bookmark with the given ID
+func (c *Client) DeleteBookmark(ctx context.Context, id string) error {
+	opts := &requestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf(URI_BOOKMARK, url.PathEscape(id)),
+	}
+	_, err := c.doRequest(ctx, opts, nil, nil)
+	return err
+}
@@ -0,0 +1,91 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// Test Bookmark returns the bookmark of the folder
+func TestBookmark(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			p := r.URL.Path[len(fmt.Sprintf(URI_LIST, "")):]
+			_ = json.NewEncoder(w).Encode(&Object{Path: p, IsFolder: p == "/Shared/Projects/Acme", LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT"})
+			return
+		}
+		if r.Method != "POST" || r.URL.Path != URI_BOOKMARKS {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		var req bookmarkRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("%s", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Path != "/Shared/Projects/Acme" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		_ = json.NewEncoder(w).Encode(&Bookmark{ID: "b1"})
+	}))
+	if _, err := client.Object("/Shared/Projects/Acme/plan.pdf").Bookmark(context.Background()); err != FolderRequired {
+		t.Errorf("unexpected error %v", err)
+	}
+	folder := client.Object("/Shared/Projects/Acme")
+	bookmark, err := folder.Bookmark(context.Background())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if bookmark.ID != "b1" || bookmark.Path != folder.Path || bookmark.Folder != folder {
+		t.Errorf("unexpected bookmark %+v", bookmark)
+	}
+}
+
+// Test ListBookmarks pages through all the bookmarks
+func TestListBookmarks(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		resp := &listBookmarksResponse{Offset: offset, TotalCount: 110}
+		for i := offset; i < offset+100 && i < resp.TotalCount; i++ {
+			resp.Bookmarks = append(resp.Bookmarks, &Bookmark{ID: strconv.Itoa(i), Path: "/Shared/" + strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	bookmarks, err := client.ListBookmarks(context.Background())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(bookmarks) != 110 {
+		t.Fatalf("%d bookmarks", len(bookmarks))
+	}
+	last := bookmarks[109]
+	if last.Folder == nil || last.Folder.Path != "/Shared/109" || !last.Folder.IsFolder || last.Folder.Client != client {
+		t.Errorf("unexpected bookmark %+v", last)
+	}
+}
+
+// Test bookmarks are created for the user a client acts as
+func TestBookmarkActAs(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(&Object{IsFolder: true})
+			return
+		}
+		if user := r.Header.Get("X-Egnyte-Act-As"); user != "newhire" {
+			t.Errorf("acting as %q", user)
+		}
+		_ = json.NewEncoder(w).Encode(&Bookmark{ID: "b1"})
+	}))
+	newHire := client.ActAs("newhire")
+	if _, err := newHire.Object("/Shared/Projects/Acme").Bookmark(context.Background()); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if _, ok := client.headers["X-Egnyte-Act-As"]; ok {
+		t.Errorf("ActAs changed the original client")
+	}
+}
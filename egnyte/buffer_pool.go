@@ -0,0 +1,116 @@
+package egnyte
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var BufferTooLarge = errors.New("buffer larger than the memory budget")
+
+// DefaultBufferPool is the pool chunk buffers are taken from when no other pool
+// is provided. It is shared by all the uploads of the process
+var DefaultBufferPool = NewBufferPool(256 * 1024 * 1024)
+
+// BufferPool hands out reusable buffers within a memory budget. When the
+// budget is exhausted, Get blocks until enough buffers are returned with Put,
+// which applies backpressure to everything sharing the pool. A nil pool
+// allocates a new buffer on every Get, without a budget
+type BufferPool struct {
+	mutex     sync.Mutex
+	budget    int64
+	allocated int64            // Size of all the buffers of the pool, in use or idle
+	idle      map[int][][]byte // Idle buffers by capacity
+	released  chan struct{}    // Closed when a buffer is returned
+}
+
+// NewBufferPool returns a BufferPool that allocates at most budget bytes
+func NewBufferPool(budget int64) *BufferPool {
+	return &BufferPool{
+		budget:   budget,
+		idle:     map[int][][]byte{},
+		released: make(chan struct{}),
+	}
+}
+
+// Get returns a buffer of size bytes, waiting for buffers to be returned to
+// the pool if there is no room in the budget for it
+func (p *BufferPool) Get(ctx context.Context, size int) ([]byte, error) {
+	if p == nil {
+		return make([]byte, size), nil
+	}
+	if int64(size) > p.budget {
+		return nil, fmt.Errorf("%w: %d bytes requested, budget is %d", BufferTooLarge, size, p.budget)
+	}
+	for {
+		p.mutex.Lock()
+		if buf := p.popIdle(size); buf != nil {
+			p.mutex.Unlock()
+			return buf, nil
+		}
+		// Idle buffers of other sizes are dropped to make room
+		for p.allocated+int64(size) > p.budget && p.dropIdle() {
+		}
+		if p.allocated+int64(size) <= p.budget {
+			p.allocated += int64(size)
+			p.mutex.Unlock()
+			return make([]byte, size), nil
+		}
+		released := p.released
+		p.mutex.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Put returns a buffer obtained from Get to the pool
+func (p *BufferPool) Put(buf []byte) {
+	if p == nil || buf == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.idle[cap(buf)] = append(p.idle[cap(buf)], buf[:cap(buf)])
+	close(p.released)
+	p.released = make(chan struct{})
+}
+
+// Allocated returns the size of all the buffers allocated by the pool
+func (p *BufferPool) Allocated() int64 {
+	if p == nil {
+		return 0
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.allocated
+}
+
+// popIdle takes an idle buffer of the size out of the pool. Must be called
+// with mutex held
+func (p *BufferPool) popIdle(size int) []byte {
+	bufs := p.idle[size]
+	if len(bufs) == 0 {
+		return nil
+	}
+	buf := bufs[len(bufs)-1]
+	p.idle[size] = bufs[:len(bufs)-1]
+	return buf
+}
+
+// dropIdle releases an idle buffer to the garbage collector. Returns false if
+// there are no idle buffers. Must be called with mutex held
+func (p *BufferPool) dropIdle() bool {
+	for size, bufs := range p.idle {
+		if len(bufs) > 0 {
+			p.idle[size] = bufs[:len(bufs)-1]
+			p.allocated -= int64(size)
+			return true
+		}
+	}
+	return false
+}
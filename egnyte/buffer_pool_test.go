@@ -0,0 +1,141 @@
+package egnyte
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test BufferPool blocks Get once the budget is exhausted
+func TestBufferPoolBackpressure(t *testing.T) {
+	pool := NewBufferPool(100)
+	first, err := pool.Get(context.Background(), 60)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	_, err = pool.Get(context.Background(), 101)
+	if !errors.Is(err, BufferTooLarge) {
+		t.Errorf("%v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = pool.Get(ctx, 60)
+	if err != context.DeadlineExceeded {
+		t.Errorf("%v", err)
+	}
+
+	got := make(chan []byte)
+	go func() {
+		buf, _ := pool.Get(context.Background(), 50)
+		got <- buf
+	}()
+	select {
+	case <-got:
+		t.Fatalf("Get did not wait for the budget")
+	case <-time.After(20 * time.Millisecond):
+	}
+	pool.Put(first)
+	if buf := <-got; len(buf) != 50 {
+		t.Errorf("%d", len(buf))
+	}
+	if pool.Allocated() > 100 {
+		t.Errorf("%d bytes allocated", pool.Allocated())
+	}
+}
+
+// Test chunks read after Init are not limited by a budget when they are never
+// released
+func TestChunkUploadInfoInitUnpooled(t *testing.T) {
+	size, chunkSize := int64(320*1024*1024), int64(32*1024*1024)
+	info := &ChunkUploadInfo{}
+	info.Init(io.LimitReader(zeroReader{}, size), size, chunkSize)
+	done := make(chan int)
+	go func() {
+		chunks := 0
+		for {
+			chunk, _, _, err := info.GetChunk()
+			if err != nil || chunk == nil {
+				break
+			}
+			chunks++
+		}
+		done <- chunks
+	}()
+	select {
+	case chunks := <-done:
+		if chunks != 10 {
+			t.Errorf("%d chunks", chunks)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("GetChunk blocked")
+	}
+}
+
+// readChunks reads files of size bytes in chunks from concurrent uploads
+// sharing pool, the way Upload does, returning the buffers to the pool if
+// release is set
+func readChunks(b *testing.B, pool *BufferPool, release bool, uploads int, size, chunkSize int64) {
+	var wg sync.WaitGroup
+	for i := 0; i < uploads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info := &ChunkUploadInfo{}
+			info.InitWithPool(io.LimitReader(zeroReader{}, size), size, chunkSize, pool)
+			for {
+				chunk, remaining, _, err := info.GetChunk()
+				if err != nil {
+					b.Error(err)
+					return
+				}
+				if chunk == nil {
+					return
+				}
+				_, _ = ioutil.Discard.Write(chunk)
+				if release {
+					info.ReleaseChunk(chunk)
+				}
+				if remaining == 0 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// zeroReader is an endless stream of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// BenchmarkChunkBuffersPooled reads chunks of 8 concurrent uploads through a
+// shared pool. The bytes allocated per operation stay flat as the chunk
+// buffers are reused, and the pool never grows past its budget
+func BenchmarkChunkBuffersPooled(b *testing.B) {
+	pool := NewBufferPool(16 * 1024 * 1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		readChunks(b, pool, true, 8, 16*1024*1024, 1024*1024)
+	}
+	b.ReportMetric(float64(pool.Allocated()), "pool-bytes")
+}
+
+// BenchmarkChunkBuffersUnpooled reads the same chunks without returning the
+// buffers, allocating a buffer for every chunk as before buffer pooling
+func BenchmarkChunkBuffersUnpooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		readChunks(b, NewBufferPool(1<<40), false, 8, 16*1024*1024, 1024*1024)
+	}
+}
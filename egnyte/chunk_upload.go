@@ -1,6 +1,7 @@
 package egnyte
 
 import (
+	"context"
 	"crypto/sha512"
 	"encoding/hex"
 	"io"
@@ -18,16 +19,41 @@ type ChunkUploadInfo struct {
 	checkSumMap    map[int]string
 	chunkSize      int64
 	lastChunk      []byte
+	pool           *BufferPool
+	readTurn       chan struct{} // Held by the worker reading the next chunk
 }
 
+// Init initializes the upload allocating a new buffer for every chunk
 func (c *ChunkUploadInfo) Init(data io.Reader, size int64, chunkSize int64) {
+	c.InitWithPool(data, size, chunkSize, nil)
+}
+
+// InitWithPool initializes the upload taking the chunk buffers from pool. The
+// buffers have to be returned with ReleaseChunk once the chunks are uploaded
+func (c *ChunkUploadInfo) InitWithPool(data io.Reader, size int64, chunkSize int64, pool *BufferPool) {
 	c.checkSumMap = make(map[int]string)
 	c.data = data
 	c.remainingBytes = size
 	c.chunkSize = chunkSize
 	c.chunkNum = 0
 	c.lastChunk = nil
+	c.pool = pool
+	c.readTurn = make(chan struct{}, 1)
+}
 
+// Resume continues an upload at chunkNum, the chunks before it having been
+// uploaded already with the given checksums. The data must be positioned at
+// the start of chunkNum
+func (c *ChunkUploadInfo) Resume(chunkNum int, checksums map[int]string) {
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
+	c.resultMutex.Lock()
+	defer c.resultMutex.Unlock()
+	c.chunkNum = chunkNum - 1
+	c.remainingBytes -= int64(chunkNum-1) * c.chunkSize
+	for num, csum := range checksums {
+		c.checkSumMap[num] = csum
+	}
 }
 
 func (c *ChunkUploadInfo) GetRemainingBytes() int64 {
@@ -38,13 +64,32 @@ func (c *ChunkUploadInfo) GetRemainingBytes() int64 {
 
 // GetChunk returns chunk
 func (c *ChunkUploadInfo) GetChunk() ([]byte, int64, int, error) {
-	c.dataMutex.Lock()
-	defer c.dataMutex.Unlock()
-	if c.remainingBytes == 0 {
+	return c.GetChunkContext(context.Background())
+}
+
+// GetChunkContext returns the next chunk, waiting for a buffer from the pool
+// until ctx is done if the memory budget of the pool is exhausted
+func (c *ChunkUploadInfo) GetChunkContext(ctx context.Context) ([]byte, int64, int, error) {
+	// Chunks are read one at a time and a buffer is only taken once it is
+	// known that there is something left to read: a worker must never wait
+	// for the pool after the last chunk, whose buffer is held until all the
+	// other chunks are uploaded. Waiting for the turn honours ctx
+	select {
+	case c.readTurn <- struct{}{}:
+	case <-ctx.Done():
+		return nil, 0, 0, ctx.Err()
+	}
+	defer func() { <-c.readTurn }()
+	if c.GetRemainingBytes() == 0 {
 		return nil, 0, 0, nil
 	}
+	buf, err := c.pool.Get(ctx, int(c.chunkSize))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	c.dataMutex.Lock()
+	defer c.dataMutex.Unlock()
 	c.chunkNum += 1
-	buf := make([]byte, c.chunkSize)
 	n, err := io.ReadFull(c.data, buf)
 	switch err {
 	case nil:
@@ -52,6 +97,7 @@ func (c *ChunkUploadInfo) GetChunk() ([]byte, int64, int, error) {
 	case io.ErrUnexpectedEOF:
 		break
 	default:
+		c.pool.Put(buf)
 		return nil, 0, 0, err
 	}
 	c.remainingBytes -= int64(n)
@@ -68,6 +114,17 @@ func (c *ChunkUploadInfo) GetLastChunk() ([]byte, int) {
 	return c.lastChunk, c.chunkNum
 }
 
+// ReleaseChunk returns the buffer of an uploaded chunk to the pool. The last
+// chunk is forgotten once released
+func (c *ChunkUploadInfo) ReleaseChunk(chunk []byte) {
+	c.dataMutex.Lock()
+	if c.lastChunk != nil && len(chunk) > 0 && &c.lastChunk[0] == &chunk[0] {
+		c.lastChunk = nil
+	}
+	c.dataMutex.Unlock()
+	c.pool.Put(chunk)
+}
+
 func (c *ChunkUploadInfo) SetChunkCheckSum(chunkNum int, csum string) {
 	c.resultMutex.Lock()
 	defer c.resultMutex.Unlock()
@@ -1,49 +1,129 @@
 package egnyte
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
-	"encoding/json"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+// PasswordEnvVar is the environment variable create_config reads the password
+// from when it is not piped through stdin
+const PasswordEnvVar = "EGNYTE_PASSWORD"
+
 var ClientId string
 var Domain string
 var Username string
 var Password string
+var PasswordStdin bool
+var OutputPath string
+var TokenStore string
+
+// tokenStores maps the supported --token-store values to the function that
+// persists the marshalled token
+var tokenStores = map[string]func(out io.Writer, path string, token []byte) error{
+	// file writes the token to the output path, readable only by the owner
+	"file": func(out io.Writer, path string, token []byte) error {
+		return ioutil.WriteFile(path, token, 0600)
+	},
+	// stdout prints the token so that it can be piped into another store
+	"stdout": func(out io.Writer, path string, token []byte) error {
+		_, err := fmt.Fprintln(out, string(token))
+		return err
+	},
+}
 
 // Command will generated config file
 // Config file contain auth and refresh token.
 var rootCmd = &cobra.Command{
-	Use:   "create_config",
-	Short: "configuration command will create a config.json",
-	Run: func(cmd *cobra.Command, args []string) {
-		config := map[string]string{"api_key": ClientId, "username": Username, "password": Password, "domain": Domain}
-		token, err := GetAccessToken(context.Background(), config)
+	Use:           "create_config",
+	Short:         "configuration command will create a config.json",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, ok := tokenStores[TokenStore]
+		if !ok {
+			return fmt.Errorf("unknown token store %q", TokenStore)
+		}
+		password, err := readPassword(cmd.InOrStdin(), cmd.ErrOrStderr())
 		if err != nil {
-			fmt.Println(err)
-			return
+			return err
 		}
-		file, _ := json.MarshalIndent(token, "", " ")
-
-		_ = ioutil.WriteFile("config.json", file, 0644)
-
+		config := map[string]string{"api_key": ClientId, "username": Username, "password": password, "domain": Domain}
+		token, err := GetAccessToken(cmd.Context(), config)
+		if err != nil {
+			return err
+		}
+		file, err := json.MarshalIndent(token, "", " ")
+		if err != nil {
+			return err
+		}
+		if err := store(cmd.OutOrStdout(), OutputPath, file); err != nil {
+			return fmt.Errorf("failed to store token: %w", err)
+		}
+		return nil
 	},
 }
 
+// readPassword returns the password from the deprecated --password flag,
+// stdin when --password-stdin is set, the EGNYTE_PASSWORD environment variable
+// or, as a last resort, a prompt with echo turned off when stdin is a terminal
+func readPassword(stdin io.Reader, prompt io.Writer) (string, error) {
+	if Password != "" {
+		return Password, nil
+	}
+	if PasswordStdin {
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		password := strings.TrimRight(line, "\r\n")
+		if password == "" {
+			return "", errors.New("no password provided on stdin")
+		}
+		return password, nil
+	}
+	if password := os.Getenv(PasswordEnvVar); password != "" {
+		return password, nil
+	}
+	file, ok := stdin.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return "", fmt.Errorf("password required: use --password-stdin, set %s or run from a terminal", PasswordEnvVar)
+	}
+	fmt.Fprint(prompt, "Password: ")
+	password, err := term.ReadPassword(int(file.Fd()))
+	fmt.Fprintln(prompt)
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
 func init() {
 	rootCmd.Flags().StringVarP(&ClientId, "clientId", "c", "", "key received after registering a developer account")
 	rootCmd.Flags().StringVarP(&Domain, "domain", "d", "", "Egnyte domain, e.g. example.egnyte.com")
 	rootCmd.Flags().StringVarP(&Username, "username", "u", "", "username of Egnyte admin user")
 	rootCmd.Flags().StringVarP(&Password, "password", "p", "", "password of the same Egnyte admin user")
+	rootCmd.Flags().BoolVar(&PasswordStdin, "password-stdin", false, "read the password from stdin")
+	rootCmd.Flags().StringVarP(&OutputPath, "output", "o", "config.json", "path of the generated config file")
+	rootCmd.Flags().StringVar(&TokenStore, "token-store", "file", "where to store the token: file or stdout")
 
+	_ = rootCmd.Flags().MarkDeprecated("password", fmt.Sprintf("it leaks into shell history, use --password-stdin or %s instead", PasswordEnvVar))
+	_ = rootCmd.MarkFlagRequired("clientId")
+	_ = rootCmd.MarkFlagRequired("domain")
+	_ = rootCmd.MarkFlagRequired("username")
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	if err := rootCmd.ExecuteContext(context.Background()); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -0,0 +1,56 @@
+package egnyte
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Test the sources of the create_config password in order of precedence
+func TestReadPassword(t *testing.T) {
+	defer func(password string, stdin bool) {
+		Password, PasswordStdin = password, stdin
+	}(Password, PasswordStdin)
+	t.Setenv(PasswordEnvVar, "from-env")
+
+	Password, PasswordStdin = "from-flag", true
+	password, err := readPassword(strings.NewReader("from-stdin\n"), &bytes.Buffer{})
+	if err != nil || password != "from-flag" {
+		t.Errorf("%q, %v", password, err)
+	}
+
+	Password = ""
+	password, err = readPassword(strings.NewReader("from-stdin\r\n"), &bytes.Buffer{})
+	if err != nil || password != "from-stdin" {
+		t.Errorf("%q, %v", password, err)
+	}
+	_, err = readPassword(strings.NewReader(""), &bytes.Buffer{})
+	if err == nil {
+		t.Errorf("empty stdin accepted")
+	}
+
+	PasswordStdin = false
+	password, err = readPassword(strings.NewReader("from-stdin\n"), &bytes.Buffer{})
+	if err != nil || password != "from-env" {
+		t.Errorf("%q, %v", password, err)
+	}
+
+	// Without a terminal there is no prompt
+	t.Setenv(PasswordEnvVar, "")
+	prompt := &bytes.Buffer{}
+	_, err = readPassword(strings.NewReader("from-stdin\n"), prompt)
+	if err == nil || prompt.Len() != 0 {
+		t.Errorf("prompted %q, %v", prompt, err)
+	}
+}
+
+// Test the stdout token store writes to the output of the command
+func TestStdoutTokenStore(t *testing.T) {
+	out := &bytes.Buffer{}
+	if err := tokenStores["stdout"](out, "unused.json", []byte(`{"access_token":"t"}`)); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if out.String() != "{\"access_token\":\"t\"}\n" {
+		t.Errorf("%q", out)
+	}
+}
@@ -0,0 +1,380 @@
+package egnyte
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var FileChanged = errors.New("file changed on the server during download")
+
+// partialSuffix is appended to the local path of a file while DownloadToFile
+// is downloading it. The Etag of the file being downloaded is stored next to
+// it with etagSuffix appended, to resume the download only if it is unchanged
+const (
+	partialSuffix = ".part"
+	etagSuffix    = ".etag"
+)
+
+// DownloadOptions are the optional settings for resumable downloads
+type DownloadOptions struct {
+	Offset     int64         // Byte to start downloading at
+	Length     int64         // Number of bytes to download, rest of the file if zero
+	Retries    int           // Number of times failed connections are resumed over the whole download, defaults to 3, negative for none
+	RetryDelay time.Duration // Wait before resuming a failed connection, defaults to a second
+}
+
+// rangeHeader returns the value of the Range header for the byte range, or
+// an empty string if the whole file is requested
+func rangeHeader(offset, length int64) string {
+	switch {
+	case length > 0:
+		return fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	case offset > 0:
+		return fmt.Sprintf("bytes=%d-", offset)
+	}
+	return ""
+}
+
+// getRange downloads length bytes of the file from offset. The whole file is
+// downloaded when both are zero. The response body is cut to the range even if
+// the server ignores the Range header
+func (o *Object) getRange(ctx context.Context, offset, length int64) (*http.Response, error) {
+	uri := fmt.Sprintf(URI_GET_FILE, o.Path)
+	params := url.Values{}
+	if o.EntryID != "" {
+		params.Set("entry_id", o.EntryID)
+	}
+	headers := map[string]string{}
+	if byteRange := rangeHeader(offset, length); byteRange != "" {
+		headers["Range"] = byteRange
+	}
+	opts := &requestOptions{
+		Method:        "GET",
+		Path:          uri,
+		DontCloseBody: true,
+		Parameters:    params,
+		ExtraHeaders:  headers,
+	}
+	resp, err := o.Client.doRequest(ctx, opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusPartialContent || (offset == 0 && length == 0) {
+		return resp, nil
+	}
+	// The server sent the whole file
+	if _, err := io.CopyN(ioutil.Discard, resp.Body, offset); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if length > 0 {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(resp.Body, length), resp.Body}
+	}
+	return resp, nil
+}
+
+// GetRange downloads length bytes of the file starting at offset, or the rest
+// of the file if length is zero
+func (o *Object) GetRange(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	resp, err := o.getRange(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetResumable downloads a file like GetRange but resumes the download from the
+// last byte read when the connection fails. The Etag and checksum of the file
+// are compared on every resume and FileChanged is returned if the file has been
+// replaced on the server in the meantime
+func (o *Object) GetResumable(ctx context.Context, dlOpts *DownloadOptions) (io.ReadCloser, error) {
+	reader := &resumableReader{
+		ctx:        ctx,
+		obj:        o,
+		retries:    3,
+		retryDelay: time.Second,
+	}
+	if dlOpts != nil {
+		reader.offset = dlOpts.Offset
+		if dlOpts.Length > 0 {
+			reader.remaining = dlOpts.Length
+			reader.limited = true
+		}
+		if dlOpts.Retries != 0 {
+			reader.retries = dlOpts.Retries
+		}
+		if dlOpts.RetryDelay > 0 {
+			reader.retryDelay = dlOpts.RetryDelay
+		}
+	}
+	err := reader.open()
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// resumableReader reads a file over as many ranged requests as needed to
+// recover from failed connections
+type resumableReader struct {
+	ctx        context.Context
+	obj        *Object
+	body       io.ReadCloser
+	offset     int64 // Position of the next byte in the file
+	remaining  int64 // Bytes left in the requested range if limited
+	limited    bool  // Whether a range or the rest of the file was requested
+	etag       string
+	checksum   string
+	retries    int // Number of resumes allowed for the whole download
+	failures   int // Number of resumes so far
+	retryDelay time.Duration
+}
+
+// open requests the rest of the range and checks that the file is the one the
+// download started with
+func (r *resumableReader) open() error {
+	resp, err := r.obj.getRange(r.ctx, r.offset, r.remaining)
+	if err != nil {
+		return err
+	}
+	etag := resp.Header.Get("Etag")
+	checksum := resp.Header.Get("X-Sha512-Checksum")
+	if r.body == nil {
+		r.etag, r.checksum = etag, checksum
+	} else if (etag != "" && etag != r.etag) || (checksum != "" && checksum != r.checksum) {
+		resp.Body.Close()
+		return fmt.Errorf("%w: %s", FileChanged, r.obj.Path)
+	}
+	r.body = resp.Body
+	return nil
+}
+
+func (r *resumableReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+		if r.limited {
+			r.remaining -= int64(n)
+			if r.remaining == 0 && err != nil {
+				return n, io.EOF
+			}
+		}
+		if err == nil || err == io.EOF || r.ctx.Err() != nil || r.failures >= r.retries {
+			return n, err
+		}
+		r.failures++
+		r.body.Close()
+		select {
+		case <-r.ctx.Done():
+			return n, r.ctx.Err()
+		case <-time.After(r.retryDelay):
+		}
+		if openErr := r.open(); openErr != nil {
+			return n, openErr
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (r *resumableReader) Close() error {
+	return r.body.Close()
+}
+
+// DownloadToFile downloads the file to localPath. The file is downloaded to
+// localPath with a ".part" suffix and renamed once complete, so that a later
+// call can resume an interrupted download from the partial local file as long
+// as the file has not changed on the server. The whole file is always
+// downloaded: the Offset and Length of dlOpts are ignored
+func (o *Object) DownloadToFile(ctx context.Context, localPath string, dlOpts *DownloadOptions) error {
+	partialPath := localPath + partialSuffix
+	etagPath := partialPath + etagSuffix
+
+	resumeOpts := DownloadOptions{}
+	if dlOpts != nil {
+		resumeOpts = *dlOpts
+	}
+	resumeOpts.Offset, resumeOpts.Length = 0, 0
+	if info, err := os.Stat(partialPath); err == nil {
+		resumeOpts.Offset = info.Size()
+	}
+	savedEtag, _ := ioutil.ReadFile(etagPath)
+
+	body, err := o.GetResumable(ctx, &resumeOpts)
+	if isStatus(err, http.StatusRequestedRangeNotSatisfiable) {
+		// The partial file is at least as large as the file on the server,
+		// it may have been downloaded completely but not renamed
+		complete, statErr := o.matchesFile(ctx, partialPath)
+		if statErr != nil {
+			return statErr
+		}
+		if complete {
+			return finishDownload(partialPath, localPath)
+		}
+		body, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	reader, _ := body.(*resumableReader)
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if reader == nil || (resumeOpts.Offset > 0 && (reader.etag == "" || reader.etag != string(savedEtag))) {
+		// The partial file is of another version of the file, start over
+		if reader != nil {
+			reader.Close()
+		}
+		resumeOpts.Offset = 0
+		body, err = o.GetResumable(ctx, &resumeOpts)
+		if err != nil {
+			return err
+		}
+		reader = body.(*resumableReader)
+		flags |= os.O_TRUNC
+	}
+	defer reader.Close()
+	err = ioutil.WriteFile(etagPath, []byte(reader.etag), 0644)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return finishDownload(partialPath, localPath)
+}
+
+// matchesFile returns whether the local file has the size and checksum of the
+// file on the server
+func (o *Object) matchesFile(ctx context.Context, localPath string) (bool, error) {
+	file, err := o.stat(ctx)
+	if err != nil {
+		return false, err
+	}
+	if file.IsFolder {
+		return false, FileRequired
+	}
+	local, err := os.Open(localPath)
+	if err != nil {
+		return false, err
+	}
+	defer local.Close()
+	info, err := local.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() != int64(file.Size) || file.Checksum == "" {
+		return false, nil
+	}
+	digest := sha512.New()
+	if _, err := io.Copy(digest, local); err != nil {
+		return false, err
+	}
+	return strings.EqualFold(hex.EncodeToString(digest.Sum(nil)), file.Checksum), nil
+}
+
+// finishDownload moves the complete partial file of DownloadToFile to
+// localPath and removes the Etag stored next to it
+func finishDownload(partialPath, localPath string) error {
+	err := os.Rename(partialPath, localPath)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(partialPath + etagSuffix)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// GetVerified downloads a file like Get and computes the SHA-512 of the content
+// while it is read. At the end of the content, an *IntegrityError is returned
+// instead of io.EOF if it does not match the checksum of the file
+func (o *Object) GetVerified(ctx context.Context) (io.ReadCloser, error) {
+	resp, err := o.getRange(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	expected := resp.Header.Get(headerChecksum)
+	if expected == "" {
+		expected, err = o.expectedChecksum(ctx)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+	return &verifyingReader{
+		body:     resp.Body,
+		digest:   sha512.New(),
+		path:     o.Path,
+		expected: expected,
+	}, nil
+}
+
+// expectedChecksum returns the checksum of the file, or of the version of the
+// file identified by the EntryID of the object
+func (o *Object) expectedChecksum(ctx context.Context) (string, error) {
+	if o.Checksum != "" {
+		return o.Checksum, nil
+	}
+	file, err := o.stat(ctx)
+	if err != nil {
+		return "", err
+	}
+	if o.EntryID == "" || o.EntryID == file.EntryID {
+		return file.Checksum, nil
+	}
+	for _, version := range file.Versions {
+		if version.EntryID == o.EntryID {
+			return version.Checksum, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s of %s", VersionNotFound, o.EntryID, o.Path)
+}
+
+// verifyingReader hashes the content read from body and compares it with the
+// expected checksum at the end
+type verifyingReader struct {
+	body     io.ReadCloser
+	digest   hash.Hash
+	path     string
+	expected string
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.digest.Write(p[:n])
+	if err == io.EOF {
+		actual := hex.EncodeToString(r.digest.Sum(nil))
+		if actual != r.expected {
+			return n, &IntegrityError{Path: r.path, Expected: r.expected, Actual: actual}
+		}
+	}
+	return n, err
+}
+
+func (r *verifyingReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,238 @@
+package egnyte
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyFile serves content with support for Range requests. The first
+// response is cut after failAfter bytes to simulate a dropped connection and
+// the Etag changes to etags[n] on the n-th request if set. With flapping set,
+// every response is cut after failAfter bytes
+type flakyFile struct {
+	mutex     sync.Mutex
+	content   []byte
+	failAfter int
+	flapping  bool
+	etags     []string
+	requests  int
+}
+
+// cutWriter fails the writes after n bytes
+type cutWriter struct {
+	http.ResponseWriter
+	n int
+}
+
+func (w *cutWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		p = p[:w.n]
+	}
+	n, _ := w.ResponseWriter.Write(p)
+	w.n -= n
+	if w.n == 0 {
+		return n, errors.New("connection dropped")
+	}
+	return n, nil
+}
+
+func (f *flakyFile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	request := f.requests
+	f.requests++
+	f.mutex.Unlock()
+	etag := "v1"
+	if request < len(f.etags) {
+		etag = f.etags[request]
+	}
+	w.Header().Set("Etag", etag)
+	if request == 0 && f.failAfter > 0 {
+		w.Header().Set("Content-Length", strconv.Itoa(len(f.content)))
+		_, _ = w.Write(f.content[:f.failAfter])
+		return
+	}
+	if f.flapping {
+		w = &cutWriter{ResponseWriter: w, n: f.failAfter}
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(f.content))
+}
+
+// Test GetRange downloads only the requested bytes
+func TestGetRange(t *testing.T) {
+	client := newTestClient(t, &flakyFile{content: []byte("0123456789")})
+	body, err := client.Object("/Shared/file.txt").GetRange(context.Background(), 2, 5)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil || string(data) != "23456" {
+		t.Errorf("%q, %v", data, err)
+	}
+}
+
+// Test GetResumable resumes a dropped download and detects a changed file
+func TestGetResumable(t *testing.T) {
+	content := bytes.Repeat([]byte("egnyte"), 10000)
+	client := newTestClient(t, &flakyFile{content: content, failAfter: 1000})
+	dlOpts := &DownloadOptions{RetryDelay: time.Millisecond}
+	body, err := client.Object("/Shared/file.txt").GetResumable(context.Background(), dlOpts)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	data, err := ioutil.ReadAll(body)
+	body.Close()
+	if err != nil || !bytes.Equal(data, content) {
+		t.Errorf("got %d bytes, %v", len(data), err)
+	}
+
+	client = newTestClient(t, &flakyFile{content: content, failAfter: 1000, etags: []string{"v1", "v2"}})
+	body, err = client.Object("/Shared/file.txt").GetResumable(context.Background(), dlOpts)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	_, err = ioutil.ReadAll(body)
+	body.Close()
+	if !errors.Is(err, FileChanged) {
+		t.Errorf("%v", err)
+	}
+}
+
+// Test Retries limits the resumes over the whole download
+func TestGetResumableRetries(t *testing.T) {
+	content := bytes.Repeat([]byte("egnyte"), 10000)
+	for _, retries := range []int{3, -1} {
+		server := &flakyFile{content: content, failAfter: 1000, flapping: true}
+		client := newTestClient(t, server)
+		dlOpts := &DownloadOptions{Retries: retries, RetryDelay: time.Millisecond}
+		body, err := client.Object("/Shared/file.txt").GetResumable(context.Background(), dlOpts)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		_, err = ioutil.ReadAll(body)
+		body.Close()
+		expected := retries + 1
+		if retries < 0 {
+			expected = 1
+		}
+		if err == nil || server.requests != expected {
+			t.Errorf("%d retries: %d requests, %v", retries, server.requests, err)
+		}
+	}
+}
+
+// Test DownloadToFile resumes from a partial local file of the same version
+func TestDownloadToFile(t *testing.T) {
+	content := []byte("0123456789")
+	server := &flakyFile{content: content}
+	client := newTestClient(t, server)
+	localPath := filepath.Join(t.TempDir(), "file.txt")
+	_ = ioutil.WriteFile(localPath+partialSuffix, content[:4], 0644)
+	_ = ioutil.WriteFile(localPath+partialSuffix+etagSuffix, []byte("v1"), 0644)
+
+	err := client.Object("/Shared/file.txt").DownloadToFile(context.Background(), localPath, nil)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	data, _ := ioutil.ReadFile(localPath)
+	if !bytes.Equal(data, content) || server.requests != 1 {
+		t.Errorf("%q after %d requests", data, server.requests)
+	}
+	if _, err := os.Stat(localPath + partialSuffix + etagSuffix); !os.IsNotExist(err) {
+		t.Errorf("etag file left behind: %v", err)
+	}
+
+	// A partial file of another version is discarded
+	_ = ioutil.WriteFile(localPath+partialSuffix, []byte("xxxx"), 0644)
+	_ = ioutil.WriteFile(localPath+partialSuffix+etagSuffix, []byte("v0"), 0644)
+	err = client.Object("/Shared/file.txt").DownloadToFile(context.Background(), localPath, nil)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	data, _ = ioutil.ReadFile(localPath)
+	if !bytes.Equal(data, content) {
+		t.Errorf("%q", data)
+	}
+}
+
+// Test DownloadToFile renames a partial file that is already complete instead
+// of downloading the file again
+func TestDownloadToFileComplete(t *testing.T) {
+	content := []byte("0123456789")
+	server := &flakyFile{content: content}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/pubapi/v1/fs/") {
+			_ = json.NewEncoder(w).Encode(&Object{
+				Size:            len(content),
+				Checksum:        SHA512Digest(content),
+				LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT",
+			})
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	localPath := filepath.Join(t.TempDir(), "file.txt")
+	_ = ioutil.WriteFile(localPath+partialSuffix, content, 0644)
+	_ = ioutil.WriteFile(localPath+partialSuffix+etagSuffix, []byte("v1"), 0644)
+
+	err := client.Object("/Shared/file.txt").DownloadToFile(context.Background(), localPath, nil)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	data, _ := ioutil.ReadFile(localPath)
+	if !bytes.Equal(data, content) || server.requests != 1 {
+		t.Errorf("%q after %d requests", data, server.requests)
+	}
+
+	// A partial file of the same size with other content is downloaded again
+	_ = ioutil.WriteFile(localPath+partialSuffix, []byte("xxxxxxxxxx"), 0644)
+	err = client.Object("/Shared/file.txt").DownloadToFile(context.Background(), localPath, nil)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	data, _ = ioutil.ReadFile(localPath)
+	if !bytes.Equal(data, content) || server.requests != 3 {
+		t.Errorf("%q after %d requests", data, server.requests)
+	}
+}
+
+// Test GetVerified detects content not matching the checksum of the file
+func TestGetVerified(t *testing.T) {
+	checksum := SHA512Digest([]byte("content"))
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerChecksum, checksum)
+		_, _ = w.Write([]byte("content"))
+	}))
+	body, err := client.Object("/Shared/file.txt").GetVerified(context.Background())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	data, err := ioutil.ReadAll(body)
+	body.Close()
+	if err != nil || string(data) != "content" {
+		t.Errorf("%q, %v", data, err)
+	}
+
+	checksum = SHA512Digest([]byte("other content"))
+	body, err = client.Object("/Shared/file.txt").GetVerified(context.Background())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	_, err = ioutil.ReadAll(body)
+	body.Close()
+	var integrityErr *IntegrityError
+	if !errors.As(err, &integrityErr) || !errors.Is(err, ChecksumMismatch) || integrityErr.Expected != checksum {
+		t.Errorf("%v", err)
+	}
+}
@@ -67,7 +67,7 @@ func (c *Client) doRequest(ctx context.Context, opts *requestOptions, request, r
 	}
 	parsedUrl.Path += opts.Path
 	parsedUrl.RawQuery = opts.Parameters.Encode()
-	req, err := http.NewRequest(opts.Method, parsedUrl.String(), opts.Body)
+	req, err := http.NewRequestWithContext(ctx, opts.Method, parsedUrl.String(), opts.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -161,3 +161,18 @@ func (c *Client) Object(path string) *Object {
 		Path:   path,
 	}
 }
+
+// ActAs returns a client making the API calls on behalf of the user with the
+// given username, e.g. to manage the bookmarks of another user. The token of
+// the client must belong to an administrator
+func (c *Client) ActAs(username string) *Client {
+	actAs := *c
+	actAs.headers = make(map[string]string, len(c.headers)+1)
+	for k, v := range c.headers {
+		actAs.headers[k] = v
+	}
+	actAs.headers["X-Egnyte-Act-As"] = username
+	actAs.Username = username
+	actAs.Email = ""
+	return &actAs
+}
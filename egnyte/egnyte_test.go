@@ -3,9 +3,22 @@ package egnyte
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
+// newTestClient returns a client talking to a local TLS server serving handler
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewClient(context.Background(), strings.TrimPrefix(server.URL, "https://"), "token", server.Client())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return client
+}
+
 // Test Create new egnyte client
 func TestNewClient(t *testing.T) {
 
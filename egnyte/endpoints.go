@@ -9,9 +9,44 @@ const (
 	URI_DELETE_OBJECT = URI_PREFIX_V1 + "fs%s"
 	URI_GET_FILE      = URI_PREFIX_V1 + "fs-content%s"
 	URI_CREATE_FOLDER = URI_PREFIX_V1 + "fs%s"
+	URI_MOVE_OBJECT   = URI_PREFIX_V1 + "fs%s"
+	URI_FOLDER_STATS  = URI_PREFIX_V1 + "fs/ids/folder/%s/stats"
+	URI_LOCK_FILE     = URI_PREFIX_V1 + "fs%s"
+	URI_FOLDER_OPTS   = URI_PREFIX_V1 + "fs%s"
 
 	URI_CHUNKED_UPLOAD = URI_PREFIX_V1 + "fs-content-chunked%s"
 
+	// Metadata URIs
+	URI_METADATA_NAMESPACE = URI_PREFIX_V1 + "properties/namespace"
+	URI_FILE_METADATA      = URI_PREFIX_V1 + "fs/ids/file/%s/properties/%s"
+	URI_FOLDER_METADATA    = URI_PREFIX_V1 + "fs/ids/folder/%s/properties/%s"
+
+	// Search URIs
+	URI_SEARCH = URI_PREFIX_V2 + "search"
+
+	// Link URIs
+	URI_LINKS      = URI_PREFIX_V1 + "links"
+	URI_LINK       = URI_PREFIX_V1 + "links/%s"
+	URI_LIST_LINKS = URI_PREFIX_V2 + "links"
+
+	// Note URIs
+	URI_NOTES = URI_PREFIX_V1 + "notes"
+	URI_NOTE  = URI_PREFIX_V1 + "notes/%s"
+
+	// Project folder URIs
+	URI_PROJECT_FOLDERS  = URI_PREFIX_V1 + "project-folders"
+	URI_PROJECT_FOLDER   = URI_PREFIX_V1 + "project-folders/%s"
+	URI_PROJECT_TEMPLATE = URI_PREFIX_V2 + "project-folders"
+
+	// Bookmark URIs
+	URI_BOOKMARKS = URI_PREFIX_V1 + "bookmarks"
+	URI_BOOKMARK  = URI_PREFIX_V1 + "bookmarks/%s"
+
+	// Trash URIs
+	URI_TRASH         = URI_PREFIX_V1 + "trash"
+	URI_TRASH_RESTORE = URI_PREFIX_V1 + "trash/restore"
+	URI_TRASH_PURGE   = URI_PREFIX_V1 + "trash/purge"
+
 	// Auth
 	URI_OAUTH = "/puboauth/token"
 
@@ -2,13 +2,34 @@ package egnyte
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 )
 
+var DestinationExists = errors.New("destination already exists")
+var MoveDeleteRestricted = errors.New("move and delete are restricted")
+var EntryIDMismatch = errors.New("object has been replaced by another version")
+var OverwriteRefused = errors.New("destination cannot be overwritten")
+
+// listPageSize is the number of children requested per page by ListAll
+const listPageSize = 1000
+
+// maxConflictRenames is the number of alternative names tried by ConflictRename
+const maxConflictRenames = 100
+
+// isStatus checks if err is an Egnyte API error with the provided status code
+func isStatus(err error, statusCode int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
 // Egnyte returns the modification time for a file as a string in the LastModifiedStr
 // variable. parseFileModTime parses this string into a time object and stores it in
 // the ModTime variable of the file object
@@ -121,29 +142,140 @@ func (o *Object) Create(ctx context.Context) (*Object, error) {
 
 // Downloads a file
 func (o *Object) Get(ctx context.Context) (io.ReadCloser, error) {
-	uri := fmt.Sprintf(URI_GET_FILE, o.Path)
-	params := url.Values{}
-	if o.EntryID != "" {
-		params.Set("entry_id", o.EntryID)
-	}
-	opts := &requestOptions{
-		Method:        "GET",
-		Path:          uri,
-		DontCloseBody: true,
-		Parameters:    params,
-	}
-	resp, err := o.Client.doRequest(ctx, opts, nil, nil)
+	resp, err := o.getRange(ctx, 0, 0)
 	if err != nil {
 		return nil, err
 	}
 	return resp.Body, nil
 }
 
-// TODO: Moves a file or folder object from it's current path to the newPath
-func (o *Object) Move(ctx context.Context, newPath string) error {
+// renamedPath returns the n-th alternative name for p used when resolving a
+// conflict by renaming, e.g. /Shared/report (1).pdf
+func renamedPath(p string, isFolder bool, n int) string {
+	ext := ""
+	if !isFolder {
+		ext = path.Ext(p)
+	}
+	return fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(p, ext), n, ext)
+}
+
+// isSameOrParent reports whether p is the same path as child or one of its
+// parents. Egnyte paths are case insensitive
+func isSameOrParent(p, child string) bool {
+	p = strings.ToLower(path.Clean(p))
+	child = strings.ToLower(path.Clean(child))
+	return p == child || strings.HasPrefix(child, strings.TrimSuffix(p, "/")+"/")
+}
+
+// overwrite deletes the file at dest so that src can take its place. Folders
+// are never overwritten, nor is a destination containing the source
+func (c *Client) overwrite(ctx context.Context, src, dest string, isFolder bool) error {
+	if isFolder {
+		return fmt.Errorf("%w: %s is a folder", OverwriteRefused, dest)
+	}
+	if isSameOrParent(dest, src) {
+		return fmt.Errorf("%w: %s contains %s", OverwriteRefused, dest, src)
+	}
+	existing, err := c.Object(dest).stat(ctx)
+	if err != nil {
+		return err
+	}
+	if existing.IsFolder {
+		return fmt.Errorf("%w: %s is a folder", OverwriteRefused, dest)
+	}
+	return c.Object(dest).Delete(ctx)
+}
+
+// resolveConflict runs op moving or copying src to dest and resolves a
+// conflict at the destination as per onConflict. It returns the path op
+// finally succeeded on
+func (c *Client) resolveConflict(ctx context.Context, src, dest string, isFolder bool, onConflict ConflictPolicy, op func(dest string) error) (string, error) {
+	err := op(dest)
+	if !isStatus(err, http.StatusConflict) {
+		return dest, err
+	}
+	switch onConflict {
+	case ConflictOverwrite:
+		err = c.overwrite(ctx, src, dest, isFolder)
+		if err != nil {
+			return "", err
+		}
+		return dest, op(dest)
+	case ConflictRename:
+		for n := 1; n <= maxConflictRenames; n++ {
+			candidate := renamedPath(dest, isFolder, n)
+			err = op(candidate)
+			if !isStatus(err, http.StatusConflict) {
+				return candidate, err
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: %s", DestinationExists, dest)
+}
+
+// doAction performs a move or copy of the object using the fs API resolving a
+// conflict at the destination as per onConflict. It returns the path of the
+// object after the action
+func (o *Object) doAction(ctx context.Context, req fsActionRequest, onConflict ConflictPolicy) (string, error) {
+	uri := fmt.Sprintf(URI_MOVE_OBJECT, o.Path)
+	return o.Client.resolveConflict(ctx, o.Path, req.Destination, o.IsFolder, onConflict, func(dest string) error {
+		req.Destination = dest
+		opts := &requestOptions{
+			Method: "POST",
+			Path:   uri,
+		}
+		_, err := o.Client.doRequest(ctx, opts, &req, nil)
+		return err
+	})
+}
+
+// checkEntryID makes sure that the object at the path is still the version
+// identified by the EntryID of the object
+func (o *Object) checkEntryID(ctx context.Context) error {
+	if o.IsFolder || o.EntryID == "" {
+		return nil
+	}
+	current, err := o.stat(ctx)
+	if err != nil {
+		return err
+	}
+	if current.EntryID != o.EntryID {
+		return fmt.Errorf("%w: %s is now at entry %s", EntryIDMismatch, o.Path, current.EntryID)
+	}
 	return nil
 }
 
+// Move moves a file or folder object from it's current path to the newPath and
+// returns the object at the new path. If the object has an EntryID, the move is
+// refused when the file at the path has been replaced by another version
+func (o *Object) Move(ctx context.Context, newPath string, moveOpts *MoveOptions) (*Object, error) {
+	if moveOpts == nil {
+		moveOpts = &MoveOptions{}
+	}
+	if o.RestrictMoveDelete {
+		return nil, fmt.Errorf("%w: %s", MoveDeleteRestricted, o.Path)
+	}
+	err := o.checkEntryID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := fsActionRequest{
+		Action:      "move",
+		Destination: newPath,
+	}
+	newPath, err = o.doAction(ctx, req, moveOpts.OnConflict)
+	if isStatus(err, http.StatusForbidden) {
+		// The object listed earlier may not reflect a restriction added since
+		if current, listErr := o.stat(ctx); listErr == nil && current.RestrictMoveDelete {
+			return nil, fmt.Errorf("%w: %s", MoveDeleteRestricted, o.Path)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o.Client.Object(newPath).stat(ctx)
+}
+
 // deletes a file or folder
 func (o *Object) Delete(ctx context.Context) error {
 	uri := fmt.Sprintf(URI_DELETE_OBJECT, o.Path)
@@ -160,17 +292,153 @@ func (o *Object) Delete(ctx context.Context) error {
 	return err
 }
 
-// TODO: Copies a file or folder object to a new path
-func (o *Object) Copy(ctx context.Context, newPath string) error {
-	return nil
+// Copy copies a file or folder object to newPath on the server and returns the
+// object at the path it was copied to. Folders are copied in a single request
+// unless a Progress callback is set, in which case the folder tree is created
+// at the destination and the files are copied one by one
+func (o *Object) Copy(ctx context.Context, newPath string, copyOpts *CopyOptions) (*Object, error) {
+	if copyOpts == nil {
+		copyOpts = &CopyOptions{}
+	}
+	var err error
+	if o.IsFolder && copyOpts.Progress != nil {
+		newPath, err = o.copyTree(ctx, newPath, copyOpts)
+	} else {
+		req := fsActionRequest{
+			Action:          "copy",
+			Destination:     newPath,
+			PreserveModTime: copyOpts.PreserveModTime,
+		}
+		newPath, err = o.doAction(ctx, req, copyOpts.OnConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o.Client.Object(newPath).stat(ctx)
+}
+
+// treeFile is a file found by listTree with its path relative to the root of
+// the tree
+type treeFile struct {
+	rel string
+	obj *Object
+}
+
+// childName returns the name of a listed child of a folder
+func childName(child *Object) string {
+	if child.Name != "" {
+		return child.Name
+	}
+	return path.Base(child.Path)
+}
+
+// listTree lists the folder and all its subfolders returning the paths of the
+// subfolders, parents first, and the files in the tree, relative to the root
+// of the tree at rel
+func (o *Object) listTree(ctx context.Context, rel string) ([]string, []treeFile, error) {
+	list, err := o.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	folders := []string{}
+	files := []treeFile{}
+	for _, file := range list.Files {
+		files = append(files, treeFile{rel: path.Join(rel, childName(file)), obj: file})
+	}
+	for _, folder := range list.Folders {
+		folderRel := path.Join(rel, childName(folder))
+		folders = append(folders, folderRel)
+		subFolders, subFiles, err := o.Client.Object(folder.Path).listTree(ctx, folderRel)
+		if err != nil {
+			return nil, nil, err
+		}
+		folders = append(folders, subFolders...)
+		files = append(files, subFiles...)
+	}
+	return folders, files, nil
 }
 
-// lists a file or folder
+// copyTree copies the folder to newPath file by file reporting the progress
+// after each file
+func (o *Object) copyTree(ctx context.Context, newPath string, copyOpts *CopyOptions) (string, error) {
+	folders, files, err := o.listTree(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	progress := CopyProgress{TotalFiles: len(files)}
+	for _, file := range files {
+		progress.TotalBytes += int64(file.obj.Size)
+	}
+
+	newPath, err = o.Client.resolveConflict(ctx, o.Path, newPath, true, copyOpts.OnConflict, func(dest string) error {
+		_, err := o.Client.Object(dest).createFolder(ctx)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, folder := range folders {
+		_, err = o.Client.Object(path.Join(newPath, folder)).createFolder(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, tf := range files {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		file := tf.obj
+		req := fsActionRequest{
+			Action:          "copy",
+			Destination:     path.Join(newPath, tf.rel),
+			PreserveModTime: copyOpts.PreserveModTime,
+		}
+		file.Client = o.Client
+		_, err = file.doAction(ctx, req, ConflictFail)
+		if err != nil {
+			return "", err
+		}
+		progress.FilesCopied++
+		progress.BytesCopied += int64(file.Size)
+		progress.Path = file.Path
+		copyOpts.Progress(progress)
+	}
+	return newPath, nil
+}
+
+// List lists a file or folder. All the children of a folder are returned by
+// requesting as many pages as needed
 func (o *Object) List(ctx context.Context) (*Object, error) {
-	url := fmt.Sprintf(URI_LIST, o.Path)
+	return o.ListAll(ctx, nil)
+}
+
+// ListPage lists a single page of the children of a folder as selected by the
+// Offset and Count of listOpts
+func (o *Object) ListPage(ctx context.Context, listOpts *ListOptions) (*Object, error) {
+	if listOpts == nil {
+		listOpts = &ListOptions{}
+	}
+	uri := fmt.Sprintf(URI_LIST, o.Path)
+	params := url.Values{}
+	if listOpts.Count > 0 {
+		params.Set("count", strconv.Itoa(listOpts.Count))
+	}
+	if listOpts.Offset > 0 {
+		params.Set("offset", strconv.Itoa(listOpts.Offset))
+	}
+	if listOpts.SortBy != "" {
+		params.Set("sort_by", string(listOpts.SortBy))
+	}
+	if listOpts.SortDirection != "" {
+		params.Set("sort_direction", string(listOpts.SortDirection))
+	}
+	if listOpts.IncludeMetadata {
+		params.Set("list_custom_metadata", "true")
+	}
 	opts := &requestOptions{
-		Method: "GET",
-		Path:   url,
+		Method:     "GET",
+		Path:       uri,
+		Parameters: params,
 	}
 	var list *Object
 	_, err := o.Client.doRequest(ctx, opts, nil, &list)
@@ -181,10 +449,129 @@ func (o *Object) List(ctx context.Context) (*Object, error) {
 	if err != nil {
 		return nil, err
 	}
+	list.setClient(o.Client)
+	list.filter(listOpts.Filter)
 	return list, nil
 }
 
+// stat returns the file or folder at the path without listing the children
+// of a folder, for callers that only need the metadata of the object
+func (o *Object) stat(ctx context.Context) (*Object, error) {
+	return o.ListPage(ctx, &ListOptions{Count: 1})
+}
+
+// folderID returns the folder ID of the folder, looking it up if the object
+// does not have it. Returns FolderRequired if the object is a file
+func (o *Object) folderID(ctx context.Context) (string, error) {
+	if o.FolderID != "" {
+		return o.FolderID, nil
+	}
+	current, err := o.stat(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !current.IsFolder {
+		return "", FolderRequired
+	}
+	return current.FolderID, nil
+}
+
+// checkFolder makes sure that the object is a folder, looking it up unless it
+// is already known to be one
+func (o *Object) checkFolder(ctx context.Context) error {
+	if o.IsFolder {
+		return nil
+	}
+	_, err := o.folderID(ctx)
+	return err
+}
+
+// checkFile makes sure that the object is a file, looking it up unless it is
+// already known to be one from its entry ID
+func (o *Object) checkFile(ctx context.Context) error {
+	if o.IsFolder {
+		return FileRequired
+	}
+	if o.EntryID != "" {
+		return nil
+	}
+	file, err := o.stat(ctx)
+	if err != nil {
+		return err
+	}
+	if file.IsFolder {
+		return FileRequired
+	}
+	return nil
+}
+
+// ListAll lists all the children of a folder by requesting pages of Count
+// children starting at Offset until the last page is reached
+func (o *Object) ListAll(ctx context.Context, listOpts *ListOptions) (*Object, error) {
+	pageOpts := ListOptions{}
+	if listOpts != nil {
+		pageOpts = *listOpts
+	}
+	if pageOpts.Count == 0 {
+		pageOpts.Count = listPageSize
+	}
+	filter := pageOpts.Filter
+	pageOpts.Filter = ListAllItems
+
+	list, err := o.ListPage(ctx, &pageOpts)
+	if err != nil {
+		return nil, err
+	}
+	// Number of children in the last page
+	listed := len(list.Folders) + len(list.Files)
+	for list.IsFolder {
+		pageOpts.Offset += listed
+		if listed == 0 || pageOpts.Offset >= list.TotalCount {
+			break
+		}
+		page, err := o.ListPage(ctx, &pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		list.Folders = append(list.Folders, page.Folders...)
+		list.Files = append(list.Files, page.Files...)
+		listed = len(page.Folders) + len(page.Files)
+	}
+	list.Count = len(list.Folders) + len(list.Files)
+	list.filter(filter)
+	return list, nil
+}
+
+// setClient sets the client on the object and the objects listed in it so that
+// they can be used for further requests
+func (o *Object) setClient(client *Client) {
+	o.Client = client
+	for _, children := range [][]*Object{o.Folders, o.Files, o.Versions} {
+		for _, child := range children {
+			child.Client = client
+		}
+	}
+}
+
+// filter drops the children of a listed folder not selected by filter
+func (o *Object) filter(filter ListFilter) {
+	switch filter {
+	case ListFilesOnly:
+		o.Folders = nil
+	case ListFoldersOnly:
+		o.Files = nil
+	}
+}
+
+// ChunkUpload uploads a chunk of a file. The chunk headers have to be set in
+// extraHeaders by the caller, see Client.Upload for a complete chunked upload
 func (o *Object) ChunkUpload(ctx context.Context, uploadInfo *UploadInfo, extraHeaders map[string]string) error {
+	_, err := o.chunkUpload(ctx, uploadInfo, extraHeaders)
+	return err
+}
+
+// chunkUpload uploads a chunk of a file and returns the response
+func (o *Object) chunkUpload(ctx context.Context, uploadInfo *UploadInfo, extraHeaders map[string]string) (*http.Response, error) {
 	uri := fmt.Sprintf(URI_CHUNKED_UPLOAD, o.Path)
 
 	utcLocation, _ := time.LoadLocation("UTC")
@@ -200,27 +587,11 @@ func (o *Object) ChunkUpload(ctx context.Context, uploadInfo *UploadInfo, extraH
 	}
 	resp, err := o.Client.doRequest(ctx, opts, nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if uploadInfo.UploadID == "" {
 		uploadInfo.UploadID = resp.Header.Get("X-Egnyte-Upload-Id")
 	}
 
-	return nil
-}
-
-// TODO: Fetch information about folder size and item counts (for both file version
-// and files inside the folder and all the subfolders)
-func (o *Object) Stats(ctx context.Context) error {
-	return nil
-}
-
-// TODO: Lock a file
-func (o *Object) Lock(ctx context.Context) error {
-	return nil
-}
-
-// TODO: Unlock a file
-func (o *Object) Unlock(ctx context.Context) error {
-	return nil
+	return resp, nil
 }
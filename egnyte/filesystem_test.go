@@ -2,11 +2,17 @@ package egnyte
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"net/http"
 	"os"
 	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -95,6 +101,84 @@ func TestDeleteFolder(t *testing.T) {
 	}
 }
 
+// Test Move folder
+func TestMoveFolder(t *testing.T) {
+	client, err := NewClient(context.Background(), Config["domain"], Config["accessToken"], http.DefaultClient)
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	if client == nil {
+		t.Errorf("%s", err)
+	}
+	uuid, _ := uuid.NewUUID()
+	obj := Object{
+		Client:   client,
+		Path:     path.Join(Config["RootPath"], fmt.Sprintf("%v", uuid)),
+		IsFolder: true,
+	}
+	_, err = obj.Create(context.Background())
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	newPath := path.Join(Config["RootPath"], fmt.Sprintf("%v-moved", uuid))
+	movedObj, err := obj.Move(context.Background(), newPath, nil)
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	if movedObj == nil || movedObj.Path != newPath {
+		t.Errorf("%+v", movedObj)
+	}
+	err = client.Object(newPath).Delete(context.Background())
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+// Test Copy folder
+func TestCopyFolder(t *testing.T) {
+	client, err := NewClient(context.Background(), Config["domain"], Config["accessToken"], http.DefaultClient)
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	if client == nil {
+		t.Errorf("%s", err)
+	}
+	uuid, _ := uuid.NewUUID()
+	obj := Object{
+		Client:   client,
+		Path:     path.Join(Config["RootPath"], fmt.Sprintf("%v", uuid)),
+		IsFolder: true,
+	}
+	_, err = obj.Create(context.Background())
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	newPath := path.Join(Config["RootPath"], fmt.Sprintf("%v-copy", uuid))
+	copiedObj, err := obj.Copy(context.Background(), newPath, &CopyOptions{OnConflict: ConflictRename})
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	if copiedObj == nil || copiedObj.Path != newPath {
+		t.Errorf("%+v", copiedObj)
+	}
+	for _, p := range []string{obj.Path, newPath} {
+		err = client.Object(p).Delete(context.Background())
+		if err != nil {
+			t.Errorf("%s", err)
+		}
+	}
+}
+
+// Test names used to resolve copy conflicts
+func TestRenamedPath(t *testing.T) {
+	if p := renamedPath("/Shared/report.pdf", false, 2); p != "/Shared/report (2).pdf" {
+		t.Errorf("%s", p)
+	}
+	if p := renamedPath("/Shared/v1.0", true, 1); p != "/Shared/v1.0 (1)" {
+		t.Errorf("%s", p)
+	}
+}
+
 // Test Get List Of File In Folder
 func TestGetListOfFileInFolder(t *testing.T) {
 	client, err := NewClient(context.Background(), Config["domain"], Config["accessToken"], http.DefaultClient)
@@ -189,3 +273,156 @@ func TestDeleteFile(t *testing.T) {
 		t.Errorf("%s", err)
 	}
 }
+
+// Test Folder Stats
+func TestFolderStats(t *testing.T) {
+	client, err := NewClient(context.Background(), Config["domain"], Config["accessToken"], http.DefaultClient)
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	if client == nil {
+		t.Errorf("%s", err)
+	}
+	obj := Object{
+		Client:   client,
+		Path:     "/Shared/",
+		IsFolder: true,
+	}
+	stats, err := obj.Stats(context.Background())
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	if stats == nil {
+		t.Errorf("%+v", stats)
+	}
+}
+
+// Test ListAll requests pages until all children are listed
+func TestListAllPages(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		page := &Object{IsFolder: true, Path: "/Shared/big", TotalCount: 5, Offset: offset, Count: count}
+		for i := offset; i < offset+count && i < page.TotalCount; i++ {
+			child := &Object{Name: fmt.Sprintf("%d", i), LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT"}
+			if i < 2 {
+				child.IsFolder = true
+				page.Folders = append(page.Folders, child)
+			} else {
+				page.Files = append(page.Files, child)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	list, err := client.Object("/Shared/big").ListAll(context.Background(), &ListOptions{Count: 2})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(list.Folders) != 2 || len(list.Files) != 3 {
+		t.Errorf("got %d folders and %d files", len(list.Folders), len(list.Files))
+	}
+	files, err := client.Object("/Shared/big").ListAll(context.Background(), &ListOptions{Count: 2, Filter: ListFilesOnly})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(files.Folders) != 0 || len(files.Files) != 3 || files.Files[0].Client != client {
+		t.Errorf("%+v", files)
+	}
+}
+
+// Test ConflictOverwrite only deletes a destination file that does not contain
+// the source
+func TestMoveOverwrite(t *testing.T) {
+	folders := map[string]bool{"/Shared/a": true, "/Shared/dir": true}
+	var deleted []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path[len(fmt.Sprintf(URI_LIST, "")):]
+		switch r.Method {
+		case "POST":
+			var req fsActionRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if len(deleted) == 0 || deleted[len(deleted)-1] != req.Destination {
+				w.WriteHeader(http.StatusConflict)
+			}
+		case "GET":
+			_ = json.NewEncoder(w).Encode(&Object{Path: p, IsFolder: folders[p], LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT"})
+		case "DELETE":
+			deleted = append(deleted, p)
+		}
+	}))
+	overwrite := &MoveOptions{OnConflict: ConflictOverwrite}
+	cases := []struct {
+		src, dest string
+		isFolder  bool
+	}{
+		{"/Shared/a/b", "/Shared/a", false},
+		{"/Shared/a/b", "/Shared/A/", false},
+		{"/Shared/x.txt", "/Shared/dir", false},
+		{"/Shared/c", "/Shared/d", true},
+	}
+	for _, c := range cases {
+		obj := client.Object(c.src)
+		obj.IsFolder = c.isFolder
+		if _, err := obj.Move(context.Background(), c.dest, overwrite); !errors.Is(err, OverwriteRefused) {
+			t.Errorf("%s to %s: %v", c.src, c.dest, err)
+		}
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("deleted %v", deleted)
+	}
+	moved, err := client.Object("/Shared/x.txt").Move(context.Background(), "/Shared/y.txt", overwrite)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if moved.Path != "/Shared/y.txt" || len(deleted) != 1 || deleted[0] != "/Shared/y.txt" {
+		t.Errorf("moved to %s, deleted %v", moved.Path, deleted)
+	}
+}
+
+// Test a folder copied file by file keeps the tree structure when the path of
+// the source differs from the path returned by the server
+func TestCopyTreePaths(t *testing.T) {
+	tree := fakeFolders{
+		"/Shared/Root":     {"sub/", "top.txt"},
+		"/Shared/Root/sub": {"inner.txt"},
+	}
+	var mutex sync.Mutex
+	created, copied := []string{}, []string{}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimSuffix(r.URL.Path[len(fmt.Sprintf(URI_LIST, "")):], "/")
+		switch {
+		case r.Method == "POST":
+			var req fsActionRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			mutex.Lock()
+			if req.Action == "copy" {
+				copied = append(copied, req.Destination)
+			} else {
+				created = append(created, p)
+				_ = json.NewEncoder(w).Encode(&Object{Path: p})
+			}
+			mutex.Unlock()
+		case strings.HasPrefix(p, "/Shared/copy"):
+			_ = json.NewEncoder(w).Encode(&Object{Path: p, IsFolder: true})
+		default:
+			// The server answers with the canonical paths of the tree
+			for canonical := range tree {
+				if strings.EqualFold(canonical, p) {
+					r.URL.Path = fmt.Sprintf(URI_LIST, canonical)
+				}
+			}
+			tree.ServeHTTP(w, r)
+		}
+	}))
+	src := client.Object("/shared/root/")
+	src.IsFolder = true
+	_, err := src.Copy(context.Background(), "/Shared/copy", &CopyOptions{Progress: func(CopyProgress) {}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	sort.Strings(copied)
+	if strings.Join(created, ",") != "/Shared/copy,/Shared/copy/sub" ||
+		strings.Join(copied, ",") != "/Shared/copy/sub/inner.txt,/Shared/copy/top.txt" {
+		t.Errorf("created %v, copied %v", created, copied)
+	}
+}
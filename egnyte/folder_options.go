@@ -0,0 +1,57 @@
+package egnyte
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Egnyte Folder Options APIs
+
+// GetFolderOptions returns the description, move and delete restriction and
+// email preferences of the folder
+func (o *Object) GetFolderOptions(ctx context.Context) (*FolderOptions, error) {
+	if err := o.checkFolder(ctx); err != nil {
+		return nil, err
+	}
+	return o.getFolderOptions(ctx)
+}
+
+// getFolderOptions requests the options of an object known to be a folder
+func (o *Object) getFolderOptions(ctx context.Context) (*FolderOptions, error) {
+	params := url.Values{}
+	params.Set("list_content", "false")
+	opts := &requestOptions{
+		Method:     "GET",
+		Path:       fmt.Sprintf(URI_FOLDER_OPTS, o.Path),
+		Parameters: params,
+	}
+	var folderOpts *FolderOptions
+	_, err := o.Client.doRequest(ctx, opts, nil, &folderOpts)
+	if err != nil {
+		return nil, err
+	}
+	return folderOpts, nil
+}
+
+// UpdateFolderOptions changes the non nil settings of update on the folder
+// and returns the resulting options
+func (o *Object) UpdateFolderOptions(ctx context.Context, update *FolderOptionsUpdate) (*FolderOptions, error) {
+	if err := o.checkFolder(ctx); err != nil {
+		return nil, err
+	}
+	opts := &requestOptions{
+		Method: "PATCH",
+		Path:   fmt.Sprintf(URI_FOLDER_OPTS, o.Path),
+	}
+	_, err := o.Client.doRequest(ctx, opts, update, nil)
+	if err != nil {
+		return nil, err
+	}
+	folderOpts, err := o.getFolderOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	o.RestrictMoveDelete = folderOpts.RestrictMoveDelete
+	return folderOpts, nil
+}
@@ -0,0 +1,55 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// Test UpdateFolderOptions only sends the changed settings
+func TestUpdateFolderOptions(t *testing.T) {
+	current := &FolderOptions{
+		Description:      "Client projects",
+		EmailPreferences: &EmailPreferences{ContentUpdates: true},
+	}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf(URI_FOLDER_OPTS, "/Shared/Projects") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.Error(w, "unexpected path", http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			var req map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("%s", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if len(req) != 1 || req["restrict_move_delete"] != true {
+				t.Errorf("unexpected request %v", req)
+			}
+			current.RestrictMoveDelete = true
+		case "GET":
+			if r.URL.Query().Get("list_content") != "false" {
+				_ = json.NewEncoder(w).Encode(&Object{Path: "/Shared/Projects", IsFolder: true})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(current)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	folder := client.Object("/Shared/Projects")
+	restrict := true
+	folderOpts, err := folder.UpdateFolderOptions(context.Background(), &FolderOptionsUpdate{RestrictMoveDelete: &restrict})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !folderOpts.RestrictMoveDelete || folderOpts.Description != "Client projects" ||
+		!folderOpts.EmailPreferences.ContentUpdates || !folder.RestrictMoveDelete {
+		t.Errorf("unexpected options %+v", folderOpts)
+	}
+}
@@ -0,0 +1,248 @@
+package egnyte
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"time"
+)
+
+// FS is a read only fs.FS over an Egnyte folder, so that the folder can be
+// used with fs.WalkDir, fs.Glob, template loaders, http.FS etc.
+// All the requests made by it use the context it was created with
+type FS struct {
+	ctx  context.Context
+	root *Object
+}
+
+// FS returns an fs.FS rooted at the folder at root
+func (c *Client) FS(ctx context.Context, root string) *FS {
+	return &FS{
+		ctx:  ctx,
+		root: c.Object(root),
+	}
+}
+
+// object returns the object for name after validating it as an fs.FS path
+func (f *FS) object(op, name string) (*Object, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return f.root.Client.Object(path.Join(f.root.Path, name)), nil
+}
+
+// pathError converts an error of an Egnyte API call to an *fs.PathError
+// wrapping the matching io/fs error where there is one
+func pathError(op, name string, err error) error {
+	switch {
+	case isStatus(err, http.StatusNotFound):
+		err = fs.ErrNotExist
+	case isStatus(err, http.StatusForbidden):
+		err = fs.ErrPermission
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// Open opens the named file or folder
+func (f *FS) Open(name string) (fs.File, error) {
+	obj, err := f.object("open", name)
+	if err != nil {
+		return nil, err
+	}
+	list, err := obj.stat(f.ctx)
+	if err != nil {
+		return nil, pathError("open", name, err)
+	}
+	info := &fileInfo{name: path.Base(name), obj: list}
+	if list.IsFolder {
+		return &dirFile{ctx: f.ctx, obj: obj, info: info}, nil
+	}
+	list.Path = obj.Path
+	return &remoteFile{ctx: f.ctx, info: info}, nil
+}
+
+// Stat returns the fs.FileInfo of the named file or folder
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	obj, err := f.object("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	list, err := obj.stat(f.ctx)
+	if err != nil {
+		return nil, pathError("stat", name, err)
+	}
+	return &fileInfo{name: path.Base(name), obj: list}, nil
+}
+
+// ReadDir reads the named folder and returns its entries sorted by name
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	obj, err := f.object("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	list, err := obj.List(f.ctx)
+	if err != nil {
+		return nil, pathError("readdir", name, err)
+	}
+	if !list.IsFolder {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: FolderRequired}
+	}
+	return dirEntries(list), nil
+}
+
+// ReadFile downloads the named file and returns its contents
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	obj, err := f.object("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	body, err := obj.Get(f.ctx)
+	if err != nil {
+		return nil, pathError("readfile", name, err)
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+// dirEntries returns the children of a listed folder as fs.DirEntry sorted by
+// name
+func dirEntries(list *Object) []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(list.Folders)+len(list.Files))
+	for _, children := range [][]*Object{list.Folders, list.Files} {
+		for _, child := range children {
+			name := child.Name
+			if name == "" {
+				name = path.Base(child.Path)
+			}
+			entries = append(entries, fs.FileInfoToDirEntry(&fileInfo{name: name, obj: child}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries
+}
+
+// fileInfo is the fs.FileInfo of an Egnyte file or folder
+type fileInfo struct {
+	name string
+	obj  *Object
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return int64(i.obj.Size) }
+func (i *fileInfo) ModTime() time.Time { return i.obj.ModTime }
+func (i *fileInfo) IsDir() bool        { return i.obj.IsFolder }
+func (i *fileInfo) Sys() interface{}   { return i.obj }
+
+func (i *fileInfo) Mode() fs.FileMode {
+	if i.obj.IsFolder {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+// remoteFile is an open Egnyte file. The file is downloaded from the current
+// offset on the first read after opening or seeking, so that it can be served
+// with http.FS including range requests
+type remoteFile struct {
+	ctx    context.Context
+	info   *fileInfo
+	body   io.ReadCloser
+	offset int64
+}
+
+func (f *remoteFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *remoteFile) Read(p []byte) (int, error) {
+	if f.offset >= f.info.Size() {
+		return 0, io.EOF
+	}
+	if f.body == nil {
+		body, err := f.info.obj.GetRange(f.ctx, f.offset, 0)
+		if err != nil {
+			return 0, pathError("read", f.info.name, err)
+		}
+		f.body = body
+	}
+	n, err := f.body.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+// Seek sets the offset of the next read. The download in progress, if any,
+// is dropped when the offset changes
+func (f *remoteFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.Size()
+	}
+	if offset < 0 {
+		return f.offset, &fs.PathError{Op: "seek", Path: f.info.name, Err: fs.ErrInvalid}
+	}
+	if offset != f.offset {
+		if err := f.Close(); err != nil {
+			return f.offset, err
+		}
+		f.offset = offset
+	}
+	return offset, nil
+}
+
+func (f *remoteFile) Close() error {
+	if f.body == nil {
+		return nil
+	}
+	body := f.body
+	f.body = nil
+	return body.Close()
+}
+
+// dirFile is an open Egnyte folder. The folder is listed on the first read
+type dirFile struct {
+	ctx     context.Context
+	obj     *Object
+	info    *fileInfo
+	entries []fs.DirEntry
+	listed  bool
+	offset  int
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dirFile) Close() error               { return nil }
+
+func (d *dirFile) Read(p []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: FileRequired}
+}
+
+// ReadDir returns the next n entries of the folder, or all the remaining ones
+// if n <= 0, as per fs.ReadDirFile
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.listed {
+		list, err := d.obj.List(d.ctx)
+		if err != nil {
+			return nil, pathError("readdir", d.info.name, err)
+		}
+		d.entries = dirEntries(list)
+		d.listed = true
+	}
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	d.offset += n
+	return remaining[:n], nil
+}
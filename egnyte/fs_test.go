@@ -0,0 +1,101 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+// Test FS against the io/fs conformance tests
+func TestFS(t *testing.T) {
+	client := newTestClient(t, fakeFolders{
+		"/Shared/site":        {"index.html", "css/", "empty/"},
+		"/Shared/site/css":    {"main.css", "print.css"},
+		"/Shared/site/empty":  {},
+		"/Shared/site/denied": {},
+	})
+	fsys := client.FS(context.Background(), "/Shared/site")
+	err := fstest.TestFS(fsys, "index.html", "css/main.css", "css/print.css", "empty")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	data, err := fs.ReadFile(fsys, "css/main.css")
+	if err != nil || string(data) != "main.css" {
+		t.Errorf("%q, %v", data, err)
+	}
+	_, err = fs.Stat(fsys, "missing.txt")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("%v", err)
+	}
+	_, err = fs.ReadDir(fsys, "denied")
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("%v", err)
+	}
+}
+
+// Test Stat reads the metadata of a large folder without listing it
+func TestFSStatLargeFolder(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("count") != "1" {
+			t.Errorf("unexpected query %v", r.URL.Query())
+		}
+		page := &Object{Path: "/Shared/big", IsFolder: true, FolderID: "f1", TotalCount: 100000}
+		page.Folders = []*Object{{Path: "/Shared/big/0", Name: "0", IsFolder: true}}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	info, err := fs.Stat(client.FS(context.Background(), "/Shared"), "big")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !info.IsDir() || requests != 1 {
+		t.Errorf("%d requests", requests)
+	}
+}
+
+// Test FS serves files with http.FileServer, which seeks to detect the content
+// type of files without an extension and to serve range requests
+func TestFSFileServer(t *testing.T) {
+	client := newTestClient(t, fakeFolders{
+		"/Shared/site": {"LICENSE", "index.html"},
+	})
+	server := httptest.NewServer(http.FileServer(http.FS(client.FS(context.Background(), "/Shared/site"))))
+	defer server.Close()
+
+	get := func(name, byteRange string) (int, string) {
+		req, err := http.NewRequest("GET", server.URL+"/"+name, nil)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if byteRange != "" {
+			req.Header.Set("Range", byteRange)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		return resp.StatusCode, string(body)
+	}
+	if status, body := get("LICENSE", ""); status != http.StatusOK || body != "LICENSE" {
+		t.Errorf("%d %q", status, body)
+	}
+	if status, body := get("LICENSE", "bytes=2-4"); status != http.StatusPartialContent || body != "CEN" {
+		t.Errorf("%d %q", status, body)
+	}
+	if status, body := get("index.html", "bytes=-4"); status != http.StatusPartialContent || body != "html" {
+		t.Errorf("%d %q", status, body)
+	}
+}
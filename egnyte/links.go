@@ -0,0 +1,206 @@
+package egnyte
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Egnyte Link APIs
+
+var RecipientsRequired = errors.New("recipients required")
+
+// CreateLink creates links to the file or folder with the given options
+// Returns one link per recipient, or a single link when there are none
+func (o *Object) CreateLink(ctx context.Context, options *LinkOptions) ([]*Link, error) {
+	isFolder := o.IsFolder
+	if !isFolder {
+		current, err := o.stat(ctx)
+		if err != nil {
+			return nil, err
+		}
+		isFolder = current.IsFolder
+	}
+	linkType := LinkFile
+	if isFolder {
+		linkType = LinkFolder
+	}
+	return o.createLink(ctx, linkType, options)
+}
+
+// createLink creates links of linkType to the object
+func (o *Object) createLink(ctx context.Context, linkType LinkType, options *LinkOptions) ([]*Link, error) {
+	if options == nil {
+		options = &LinkOptions{}
+	}
+	accessibility := options.Accessibility
+	if accessibility == "" {
+		accessibility = LinkAnyone
+	}
+	if accessibility == LinkRecipients && len(options.Recipients) == 0 {
+		return nil, fmt.Errorf("%w: %s", RecipientsRequired, o.Path)
+	}
+	req := &createLinkRequest{
+		Path:          o.Path,
+		Type:          linkType,
+		Accessibility: accessibility,
+		Password:      options.Password,
+		Recipients:    options.Recipients,
+		SendEmail:     options.SendEmail,
+		Message:       options.Message,
+		CopyMe:        options.CopyMe,
+		Notify:        options.Notify,
+		LinkToCurrent: options.LinkToCurrent && linkType == LinkFile,
+		ExpiryClicks:  options.ExpiryClicks,
+	}
+	if !options.ExpiryDate.IsZero() {
+		req.ExpiryDate = options.ExpiryDate.Format("2006-01-02")
+	}
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   URI_LINKS,
+	}
+	var linkResp *createLinkResponse
+	_, err := o.Client.doRequest(ctx, opts, req, &linkResp)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*Link, 0, len(linkResp.Links))
+	for _, created := range linkResp.Links {
+		link := linkResp.Link
+		link.ID = created.ID
+		link.URL = created.URL
+		link.Recipients = created.Recipients
+		links = append(links, &link)
+	}
+	return links, nil
+}
+
+// GetLink returns the details of the link with the given ID
+func (c *Client) GetLink(ctx context.Context, id string) (*Link, error) {
+	opts := &requestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf(URI_LINK, url.PathEscape(id)),
+	}
+	var link *Link
+	_, err := c.doRequest(ctx, opts, nil, &link)
+	if err != nil {
+		return nil, err
+	}
+	if link.ID == "" {
+		link.ID = id
+	}
+	return link, nil
+}
+
+// DeleteLink deletes the link with the given ID
+func (c *Client) DeleteLink(ctx context.Context, id string) error {
+	opts := &requestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf(URI_LINK, url.PathEscape(id)),
+	}
+	_, err := c.doRequest(ctx, opts, nil, nil)
+	return err
+}
+
+// ListLinksPage lists a page of at most count links starting at offset and
+// returns them with the total number of links matching the filter
+func (c *Client) ListLinksPage(ctx context.Context, filter *LinkFilter, offset, count int) ([]*Link, int, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("count", strconv.Itoa(count))
+	if filter != nil {
+		if filter.Path != "" {
+			params.Set("path", filter.Path)
+		}
+		if filter.Username != "" {
+			params.Set("username", filter.Username)
+		}
+		if filter.Type != "" {
+			params.Set("type", string(filter.Type))
+		}
+		if filter.Accessibility != "" {
+			params.Set("accessibility", string(filter.Accessibility))
+		}
+		if !filter.CreatedAfter.IsZero() {
+			params.Set("created_after", filter.CreatedAfter.UTC().Format(time.RFC3339))
+		}
+		if !filter.CreatedBefore.IsZero() {
+			params.Set("created_before", filter.CreatedBefore.UTC().Format(time.RFC3339))
+		}
+	}
+	opts := &requestOptions{
+		Method:     "GET",
+		Path:       URI_LIST_LINKS,
+		Parameters: params,
+	}
+	var linksResp *listLinksResponse
+	_, err := c.doRequest(ctx, opts, nil, &linksResp)
+	if err != nil {
+		return nil, 0, err
+	}
+	return linksResp.Links, linksResp.TotalCount, nil
+}
+
+// ListLinks lists the links matching the filter
+// Returns all links by iterating through all available pages
+func (c *Client) ListLinks(ctx context.Context, filter *LinkFilter) ([]*Link, error) {
+	var links []*Link
+	offset := 0
+	itemsPerPage := 100
+	for {
+		page, total, err := c.ListLinksPage(ctx, filter, offset, itemsPerPage)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, page...)
+		offset += len(page)
+		if len(page) == 0 || offset >= total {
+			break
+		}
+	}
+	return links, nil
+}
+
+// CreateUploadLink creates links through which anyone, or only the holders of
+// the password when options.Password is set, can upload files into the folder
+// Returns one link per recipient, or a single link when there are none
+func (o *Object) CreateUploadLink(ctx context.Context, options *LinkOptions) ([]*Link, error) {
+	if err := o.checkFolder(ctx); err != nil {
+		return nil, err
+	}
+	uploadOpts := LinkOptions{}
+	if options != nil {
+		uploadOpts = *options
+	}
+	if uploadOpts.Accessibility == "" && uploadOpts.Password != "" {
+		uploadOpts.Accessibility = LinkPassword
+	}
+	return o.createLink(ctx, LinkUpload, &uploadOpts)
+}
+
+// ListUploadLinks lists the upload links to the folder
+func (o *Object) ListUploadLinks(ctx context.Context) ([]*Link, error) {
+	if err := o.checkFolder(ctx); err != nil {
+		return nil, err
+	}
+	return o.Client.ListLinks(ctx, &LinkFilter{Path: o.Path, Type: LinkUpload})
+}
+
+// RevokeUploadLinks deletes all the upload links to the folder
+func (o *Object) RevokeUploadLinks(ctx context.Context) error {
+	links, err := o.ListUploadLinks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if err := o.Client.DeleteLink(ctx, link.ID); err != nil && !isStatus(err, http.StatusNotFound) {
+			return err
+		}
+	}
+	return nil
+}
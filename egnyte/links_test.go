@@ -0,0 +1,134 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Test CreateLink sends the link settings and returns one link per recipient
+func TestCreateLink(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == fmt.Sprintf(URI_LIST, "/Shared/Projects") {
+			_ = json.NewEncoder(w).Encode(&Object{Path: "/Shared/Projects", IsFolder: true})
+			return
+		}
+		if r.Method != "POST" || r.URL.Path != URI_LINKS {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		var req createLinkRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("%s", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Type != LinkFolder || req.Accessibility != LinkRecipients || req.ExpiryDate != "2024-06-30" ||
+			req.SendEmail == nil || !*req.SendEmail || req.Notify != nil || req.CopyMe != nil {
+			t.Errorf("unexpected request %+v", req)
+		}
+		resp := map[string]interface{}{
+			"path":          req.Path,
+			"type":          req.Type,
+			"accessibility": req.Accessibility,
+			"expiry_date":   req.ExpiryDate,
+			"created_by":    "admin",
+		}
+		var links []map[string]interface{}
+		for i, recipient := range req.Recipients {
+			links = append(links, map[string]interface{}{
+				"id":         strconv.Itoa(i),
+				"url":        fmt.Sprintf("https://example.egnyte.com/fl/%d", i),
+				"recipients": []string{recipient},
+			})
+		}
+		resp["links"] = links
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	folder := client.Object("/Shared/Projects")
+	sendEmail := true
+	links, err := folder.CreateLink(context.Background(), &LinkOptions{
+		Accessibility: LinkRecipients,
+		Recipients:    []string{"a@example.com", "b@example.com"},
+		SendEmail:     &sendEmail,
+		ExpiryDate:    time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(links) != 2 || links[1].ID != "1" || links[1].Recipients[0] != "b@example.com" ||
+		links[1].Path != "/Shared/Projects" || links[1].CreatedBy != "admin" {
+		t.Errorf("unexpected links %+v", links)
+	}
+
+	_, err = folder.CreateLink(context.Background(), &LinkOptions{Accessibility: LinkRecipients})
+	if !errors.Is(err, RecipientsRequired) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// Test ListLinks pages through all the links matching the filter
+func TestListLinks(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("path") != "/Shared/Projects" || query.Get("type") != "folder" {
+			t.Errorf("unexpected filter %v", query)
+		}
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		resp := &listLinksResponse{Offset: offset, TotalCount: 120}
+		for i := offset; i < offset+100 && i < resp.TotalCount; i++ {
+			resp.Links = append(resp.Links, &Link{ID: strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	links, err := client.ListLinks(context.Background(), &LinkFilter{Path: "/Shared/Projects", Type: LinkFolder})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(links) != 120 || links[119].ID != "119" {
+		t.Errorf("%d links", len(links))
+	}
+}
+
+// Test RevokeUploadLinks deletes every upload link to the folder
+func TestRevokeUploadLinks(t *testing.T) {
+	deleted := map[string]bool{}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == URI_LIST_LINKS:
+			query := r.URL.Query()
+			if query.Get("path") != "/Shared/Inbox" || query.Get("type") != "upload" {
+				t.Errorf("unexpected filter %v", query)
+			}
+			_ = json.NewEncoder(w).Encode(&listLinksResponse{
+				TotalCount: 2,
+				Links:      []*Link{{ID: "a", Type: LinkUpload}, {ID: "b", Type: LinkUpload}},
+			})
+		case r.Method == "GET" && r.URL.Path == fmt.Sprintf(URI_LIST, "/Shared/Inbox"):
+			_ = json.NewEncoder(w).Encode(&Object{Path: "/Shared/Inbox", IsFolder: true})
+		case r.Method == "GET" && r.URL.Path == fmt.Sprintf(URI_LIST, "/Shared/form.pdf"):
+			_ = json.NewEncoder(w).Encode(&Object{Path: "/Shared/form.pdf", LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT"})
+		case r.Method == "DELETE":
+			deleted[r.URL.Path] = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	if err := client.Object("/Shared/form.pdf").RevokeUploadLinks(context.Background()); err != FolderRequired {
+		t.Errorf("unexpected error %v", err)
+	}
+	folder := client.Object("/Shared/Inbox")
+	if err := folder.RevokeUploadLinks(context.Background()); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !deleted[fmt.Sprintf(URI_LINK, "a")] || !deleted[fmt.Sprintf(URI_LINK, "b")] {
+		t.Errorf("deleted %v", deleted)
+	}
+}
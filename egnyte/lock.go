@@ -0,0 +1,148 @@
+package egnyte
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var FileRequired = errors.New("object must be a file")
+
+// unlockTimeout bounds the release of a lock held by WithLock, which is done
+// even after the caller's context is cancelled
+const unlockTimeout = 30 * time.Second
+
+// lockTimeout rounds timeout up to whole seconds, the unit of the lock API
+func lockTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return 0
+	}
+	return (timeout + time.Second - 1).Truncate(time.Second)
+}
+
+// lockAction locks or unlocks the file with the provided token
+func (o *Object) lockAction(ctx context.Context, action, lockToken string, timeout time.Duration) error {
+	if o.IsFolder {
+		return FileRequired
+	}
+	uri := fmt.Sprintf(URI_LOCK_FILE, o.Path)
+	req := fsActionRequest{
+		Action:      action,
+		LockToken:   lockToken,
+		LockTimeout: int64(timeout / time.Second),
+	}
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   uri,
+	}
+	_, err := o.Client.doRequest(ctx, opts, &req, nil)
+	return err
+}
+
+// Lock locks the file for timeout (server default if zero), rounded up to
+// whole seconds. A lock token is generated if lockToken is empty. Locking a file again with the token of a
+// lock held on it extends the lock
+func (o *Object) Lock(ctx context.Context, lockToken string, timeout time.Duration) (*FileLock, error) {
+	if lockToken == "" {
+		lockToken = uuid.New().String()
+	}
+	timeout = lockTimeout(timeout)
+	err := o.lockAction(ctx, "lock", lockToken, timeout)
+	if err != nil {
+		return nil, err
+	}
+	o.Locked = true
+	lock := &FileLock{
+		Path:    o.Path,
+		Token:   lockToken,
+		Timeout: timeout,
+	}
+	if timeout > 0 {
+		lock.Expires = time.Now().Add(timeout)
+	}
+	return lock, nil
+}
+
+// RenewLock extends a lock held on the file by its timeout
+func (o *Object) RenewLock(ctx context.Context, lock *FileLock) (*FileLock, error) {
+	err := o.lockAction(ctx, "lock", lock.Token, lock.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	renewed := *lock
+	if renewed.Timeout > 0 {
+		renewed.Expires = time.Now().Add(renewed.Timeout)
+	}
+	return &renewed, nil
+}
+
+// Unlock releases the lock identified by lockToken on the file
+func (o *Object) Unlock(ctx context.Context, lockToken string) error {
+	err := o.lockAction(ctx, "unlock", lockToken, 0)
+	if err != nil {
+		return err
+	}
+	o.Locked = false
+	return nil
+}
+
+// WithLock locks the file, calls fn and releases the lock once fn returns or
+// panics. The lock is renewed in the background halfway through every
+// timeout; the context passed to fn is cancelled if a renewal fails, so that
+// fn can stop working on a file it no longer holds, or if ctx is cancelled.
+// The lock is held until fn returns in both cases
+func (o *Object) WithLock(ctx context.Context, timeout time.Duration, fn func(ctx context.Context, lock *FileLock) error) (err error) {
+	lock, err := o.Lock(ctx, "", timeout)
+	if err != nil {
+		return err
+	}
+	fnCtx, cancel := context.WithCancel(ctx)
+	renewErr := make(chan error, 1)
+	renewDone := make(chan struct{})
+	defer func() {
+		cancel()
+		<-renewDone
+		// ctx may already be cancelled but the lock still has to be released
+		unlockCtx, unlockCancel := context.WithTimeout(context.Background(), unlockTimeout)
+		defer unlockCancel()
+		unlockErr := o.Unlock(unlockCtx, lock.Token)
+		select {
+		case rErr := <-renewErr:
+			if err == nil {
+				err = rErr
+			}
+		default:
+		}
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+
+	go func() {
+		defer close(renewDone)
+		if lock.Timeout <= 0 {
+			return
+		}
+		ticker := time.NewTicker(lock.Timeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-fnCtx.Done():
+				return
+			case <-ticker.C:
+				if _, err := o.RenewLock(fnCtx, lock); err != nil {
+					if fnCtx.Err() == nil {
+						renewErr <- fmt.Errorf("failed to renew lock on %s: %w", o.Path, err)
+						cancel()
+					}
+					return
+				}
+			}
+		}
+	}()
+
+	return fn(fnCtx, lock)
+}
@@ -0,0 +1,77 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test WithLock releases the lock when the callback panics
+func TestWithLockReleasesOnPanic(t *testing.T) {
+	var mutex sync.Mutex
+	actions := []string{}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req fsActionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mutex.Lock()
+		actions = append(actions, req.Action)
+		mutex.Unlock()
+	}))
+	obj := client.Object("/Shared/test/locked.txt")
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic to propagate")
+			}
+		}()
+		_ = obj.WithLock(context.Background(), time.Hour, func(ctx context.Context, lock *FileLock) error {
+			panic("boom")
+		})
+	}()
+
+	if len(actions) != 2 || actions[0] != "lock" || actions[1] != "unlock" {
+		t.Errorf("%v", actions)
+	}
+	if obj.Locked {
+		t.Errorf("object still marked as locked")
+	}
+}
+
+// Test WithLock renews the lock while the callback runs, with the timeout
+// rounded up to the whole seconds sent to the server
+func TestWithLockRenews(t *testing.T) {
+	var mutex sync.Mutex
+	locks := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req fsActionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Action == "lock" {
+			if req.LockTimeout != 1 {
+				t.Errorf("lock timeout %d", req.LockTimeout)
+			}
+			mutex.Lock()
+			locks++
+			mutex.Unlock()
+		}
+	}))
+	obj := client.Object("/Shared/test/locked.txt")
+	err := obj.WithLock(context.Background(), 40*time.Millisecond, func(ctx context.Context, lock *FileLock) error {
+		if lock.Timeout != time.Second {
+			t.Errorf("lock timeout %s", lock.Timeout)
+		}
+		time.Sleep(700 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if locks < 2 {
+		t.Errorf("lock was not renewed, %d lock requests", locks)
+	}
+}
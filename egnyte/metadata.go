@@ -0,0 +1,141 @@
+package egnyte
+
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+// Egnyte Custom Metadata APIs
+
+var validMetadataKeyTypes = []MetadataKeyType{
+	MetadataString,
+	MetadataInteger,
+	MetadataDecimal,
+	MetadataDate,
+	MetadataEnum,
+}
+
+// isValidMetadataKeyType checks if the provided type is a valid metadata key type or not
+func isValidMetadataKeyType(keyType MetadataKeyType) bool {
+	for _, validType := range validMetadataKeyTypes {
+		if validType == keyType {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateMetadataNamespace creates a custom metadata namespace with its keys
+func (c *Client) CreateMetadataNamespace(ctx context.Context, namespace *MetadataNamespace) error {
+	for name, key := range namespace.Keys {
+		if !isValidMetadataKeyType(key.Type) {
+			return fmt.Errorf("%s is not a valid metadata key type for %s", key.Type, name)
+		}
+		if key.Type == MetadataEnum && len(key.Values) == 0 {
+			return fmt.Errorf("enum key %s must have allowed values", name)
+		}
+	}
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   URI_METADATA_NAMESPACE,
+	}
+	_, err := c.doRequest(ctx, opts, namespace, nil)
+	return err
+}
+
+// ListMetadataNamespaces lists all the custom metadata namespaces of the domain
+func (c *Client) ListMetadataNamespaces(ctx context.Context) ([]*MetadataNamespace, error) {
+	opts := &requestOptions{
+		Method: "GET",
+		Path:   URI_METADATA_NAMESPACE,
+	}
+	var namespacesResp *listNamespacesResponse
+	_, err := c.doRequest(ctx, opts, nil, &namespacesResp)
+	if err != nil {
+		return nil, err
+	}
+	return namespacesResp.Namespaces, nil
+}
+
+// DeleteMetadataNamespace deletes a custom metadata namespace along with its
+// values on all files and folders
+func (c *Client) DeleteMetadataNamespace(ctx context.Context, name string) error {
+	opts := &requestOptions{
+		Method: "DELETE",
+		Path:   path.Join(URI_METADATA_NAMESPACE, name),
+	}
+	_, err := c.doRequest(ctx, opts, nil, nil)
+	return err
+}
+
+// metadataURI returns the URI of the metadata values of the object in the
+// namespace. Metadata is addressed by the group ID of a file or the folder ID
+// of a folder, which are looked up if the object does not have them
+func (o *Object) metadataURI(ctx context.Context, namespace string) (string, error) {
+	id := o.GroupID
+	if o.IsFolder {
+		id = o.FolderID
+	}
+	isFolder := o.IsFolder
+	if id == "" {
+		current, err := o.stat(ctx)
+		if err != nil {
+			return "", err
+		}
+		id, isFolder = current.GroupID, current.IsFolder
+		if isFolder {
+			id = current.FolderID
+		}
+	}
+	if isFolder {
+		return fmt.Sprintf(URI_FOLDER_METADATA, id, namespace), nil
+	}
+	return fmt.Sprintf(URI_FILE_METADATA, id, namespace), nil
+}
+
+// SetMetadata sets the values of keys of the namespace on the object
+func (o *Object) SetMetadata(ctx context.Context, namespace string, values map[string]interface{}) error {
+	uri, err := o.metadataURI(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	opts := &requestOptions{
+		Method: "PUT",
+		Path:   uri,
+	}
+	_, err = o.Client.doRequest(ctx, opts, values, nil)
+	return err
+}
+
+// GetMetadata returns the values of the keys of the namespace set on the object
+func (o *Object) GetMetadata(ctx context.Context, namespace string) (map[string]interface{}, error) {
+	uri, err := o.metadataURI(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	opts := &requestOptions{
+		Method: "GET",
+		Path:   uri,
+	}
+	var values map[string]interface{}
+	_, err = o.Client.doRequest(ctx, opts, nil, &values)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// DeleteMetadata deletes all the values of the namespace set on the object
+func (o *Object) DeleteMetadata(ctx context.Context, namespace string) error {
+	uri, err := o.metadataURI(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	opts := &requestOptions{
+		Method: "DELETE",
+		Path:   uri,
+	}
+	_, err = o.Client.doRequest(ctx, opts, nil, nil)
+	return err
+}
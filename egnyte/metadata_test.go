@@ -0,0 +1,43 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Test CreateMetadataNamespace rejects invalid keys
+func TestCreateMetadataNamespaceValidation(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	for _, key := range []*MetadataKey{{Type: "boolean"}, {Type: MetadataEnum}} {
+		namespace := &MetadataNamespace{Name: "contracts", Keys: map[string]*MetadataKey{"key": key}}
+		if err := client.CreateMetadataNamespace(context.Background(), namespace); err == nil {
+			t.Errorf("%+v accepted", key)
+		}
+	}
+}
+
+// Test SetMetadata looks up the ID of the object to address its metadata
+func TestSetMetadata(t *testing.T) {
+	var values map[string]interface{}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pubapi/v1/fs/Shared/contract.pdf":
+			_ = json.NewEncoder(w).Encode(&Object{GroupID: "group-1", LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT"})
+		case "/pubapi/v1/fs/ids/file/group-1/properties/contracts":
+			_ = json.NewDecoder(r.Body).Decode(&values)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	err := client.Object("/Shared/contract.pdf").SetMetadata(context.Background(), "contracts", map[string]interface{}{"value": 1000})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if values["value"] != float64(1000) {
+		t.Errorf("%v", values)
+	}
+}
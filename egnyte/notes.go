@@ -0,0 +1,107 @@
+package egnyte
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Egnyte Note APIs
+
+// AddNote adds a note with the given message to the file
+func (o *Object) AddNote(ctx context.Context, message string) (*Note, error) {
+	if err := o.checkFile(ctx); err != nil {
+		return nil, err
+	}
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   URI_NOTES,
+	}
+	req := &addNoteRequest{
+		Path: o.Path,
+		Body: message,
+	}
+	var noteResp *addNoteResponse
+	_, err := o.Client.doRequest(ctx, opts, req, &noteResp)
+	if err != nil {
+		return nil, err
+	}
+	return o.Client.GetNote(ctx, noteResp.ID)
+}
+
+// ListNotes lists the notes on the file
+func (o *Object) ListNotes(ctx context.Context) ([]*Note, error) {
+	if err := o.checkFile(ctx); err != nil {
+		return nil, err
+	}
+	return o.Client.ListNotes(ctx, o.Path)
+}
+
+// GetNote returns the note with the given ID
+func (c *Client) GetNote(ctx context.Context, id string) (*Note, error) {
+	opts := &requestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf(URI_NOTE, url.PathEscape(id)),
+	}
+	var note *Note
+	_, err := c.doRequest(ctx, opts, nil, &note)
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// DeleteNote deletes the note with the given ID
+func (c *Client) DeleteNote(ctx context.Context, id string) error {
+	opts := &requestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf(URI_NOTE, url.PathEscape(id)),
+	}
+	_, err := c.doRequest(ctx, opts, nil, nil)
+	return err
+}
+
+// ListNotesPage lists a page of at most count notes starting at offset on the
+// file at path, or on all files of the domain if path is empty, and returns
+// them with the total number of notes
+func (c *Client) ListNotesPage(ctx context.Context, path string, offset, count int) ([]*Note, int, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("count", strconv.Itoa(count))
+	if path != "" {
+		params.Set("file", path)
+	}
+	opts := &requestOptions{
+		Method:     "GET",
+		Path:       URI_NOTES,
+		Parameters: params,
+	}
+	var notesResp *listNotesResponse
+	_, err := c.doRequest(ctx, opts, nil, &notesResp)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notesResp.Notes, notesResp.TotalResults, nil
+}
+
+// ListNotes lists the notes on the file at path, or on all files of the
+// domain if path is empty
+// Returns all notes by iterating through all available pages
+func (c *Client) ListNotes(ctx context.Context, path string) ([]*Note, error) {
+	var notes []*Note
+	offset := 0
+	itemsPerPage := 100
+	for {
+		page, total, err := c.ListNotesPage(ctx, path, offset, itemsPerPage)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, page...)
+		offset += len(page)
+		if len(page) == 0 || offset >= total {
+			break
+		}
+	}
+	return notes, nil
+}
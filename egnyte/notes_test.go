@@ -0,0 +1,95 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// serveNoteTargets serves the metadata of the file /Shared/spec.pdf and the
+// folder /Shared/docs and returns whether r was such a lookup
+func serveNoteTargets(w http.ResponseWriter, r *http.Request) bool {
+	switch r.URL.Path {
+	case "/pubapi/v1/fs/Shared/spec.pdf":
+		_ = json.NewEncoder(w).Encode(&Object{Path: "/Shared/spec.pdf", LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT"})
+	case "/pubapi/v1/fs/Shared/docs":
+		_ = json.NewEncoder(w).Encode(&Object{Path: "/Shared/docs", IsFolder: true})
+	default:
+		return false
+	}
+	return true
+}
+
+// Test AddNote returns the created note and refuses to add notes to folders
+func TestAddNote(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveNoteTargets(w, r) {
+			return
+		}
+		switch {
+		case r.Method == "POST" && r.URL.Path == URI_NOTES:
+			var req addNoteRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("%s", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if req.Path != "/Shared/spec.pdf" || req.Body != "approved" {
+				t.Errorf("unexpected request %+v", req)
+			}
+			_ = json.NewEncoder(w).Encode(&addNoteResponse{ID: "n1"})
+		case r.Method == "GET" && r.URL.Path == fmt.Sprintf(URI_NOTE, "n1"):
+			_, _ = w.Write([]byte(`{"id":"n1","file_path":"/Shared/spec.pdf","message":"approved",` +
+				`"username":"jdoe","creation_time":"2024-03-01T10:00:00.000-07:00"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	note, err := client.Object("/Shared/spec.pdf").AddNote(context.Background(), "approved")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if note.ID != "n1" || note.Username != "jdoe" || note.CreatedAt.UTC().Hour() != 17 {
+		t.Errorf("unexpected note %+v", note)
+	}
+
+	_, err = client.Object("/Shared/docs").AddNote(context.Background(), "approved")
+	if !errors.Is(err, FileRequired) {
+		t.Errorf("%v", err)
+	}
+}
+
+// Test ListNotes pages through all the notes on a file
+func TestListNotes(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveNoteTargets(w, r) {
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("file") != "/Shared/spec.pdf" {
+			t.Errorf("unexpected query %v", query)
+		}
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		resp := &listNotesResponse{Offset: offset, TotalResults: 130}
+		for i := offset; i < offset+100 && i < resp.TotalResults; i++ {
+			resp.Notes = append(resp.Notes, &Note{ID: strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	notes, err := client.Object("/Shared/spec.pdf").ListNotes(context.Background())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(notes) != 130 || notes[129].ID != "129" {
+		t.Errorf("%d notes", len(notes))
+	}
+
+	_, err = client.Object("/Shared/docs").ListNotes(context.Background())
+	if !errors.Is(err, FileRequired) {
+		t.Errorf("%v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package egnyte
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDownloadWorkers  = 4
+	defaultDownloadPartSize = 8 * 1024 * 1024
+	defaultDownloadRetries  = 3
+	defaultDownloadBackoff  = 500 * time.Millisecond
+)
+
+// DownloadParallel downloads the file into w by splitting it into ranges that
+// are fetched by concurrent workers. A range that fails is retried on its own.
+// Once all the ranges are written, the SHA-512 of the content is compared with
+// the checksum of the file on the server and an *IntegrityError is returned if
+// they differ
+func (o *Object) DownloadParallel(ctx context.Context, w io.WriterAt, pdOpts *ParallelDownloadOptions) error {
+	workers, partSize := defaultDownloadWorkers, int64(defaultDownloadPartSize)
+	retries, backoff := defaultDownloadRetries, defaultDownloadBackoff
+	if pdOpts != nil {
+		if pdOpts.Workers > 0 {
+			workers = pdOpts.Workers
+		}
+		if pdOpts.PartSize > 0 {
+			partSize = pdOpts.PartSize
+		}
+		if pdOpts.Retries != 0 {
+			retries = pdOpts.Retries
+		}
+		if pdOpts.RetryDelay > 0 {
+			backoff = pdOpts.RetryDelay
+		}
+	}
+	file, err := o.stat(ctx)
+	if err != nil {
+		return err
+	}
+	if file.IsFolder {
+		return FileRequired
+	}
+	// All the ranges are read from the version listed now
+	version := &Object{Client: o.Client, Path: o.Path, EntryID: file.EntryID}
+	size := int64(file.Size)
+	parts := int((size + partSize - 1) / partSize)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	d := &parallelDownload{
+		ctx:     ctx,
+		hash:    sha512.New(),
+		retries: retries,
+		backoff: backoff,
+	}
+	d.hashed = sync.NewCond(&d.mutex)
+	// Wake up the workers waiting for their turn to hash once cancelled
+	go func() {
+		<-ctx.Done()
+		d.mutex.Lock()
+		d.hashed.Broadcast()
+		d.mutex.Unlock()
+	}()
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < parts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for part := range next {
+				start := int64(part) * partSize
+				length := partSize
+				if start+length > size {
+					length = size - start
+				}
+				err := d.downloadPart(version, w, part, start, length)
+				if err != nil {
+					d.fail(err)
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+feed:
+	for part := 0; part < parts; part++ {
+		select {
+		case next <- part:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if d.err != nil {
+		return d.err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	checksum := hex.EncodeToString(d.hash.Sum(nil))
+	if file.Checksum != "" && file.Checksum != checksum {
+		return &IntegrityError{Path: o.Path, Expected: file.Checksum, Actual: checksum}
+	}
+	return nil
+}
+
+// parallelDownload is the state shared by the workers of DownloadParallel
+type parallelDownload struct {
+	ctx     context.Context
+	retries int           // Number of retries of each part, none if negative
+	backoff time.Duration // Wait before the first retry of a part, doubled on every retry
+	mutex   sync.Mutex
+	hashed  *sync.Cond // Signalled when a part has been added to hash
+	hash    hash.Hash
+	next    int // Index of the part to be hashed next
+	err     error
+}
+
+// fail records the first error of the download
+func (d *parallelDownload) fail(err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// downloadPart downloads a range of the file retrying it on failure, writes it
+// at its position in w and adds it to the hash once the parts before it are
+func (d *parallelDownload) downloadPart(file *Object, w io.WriterAt, part int, start, length int64) error {
+	data := make([]byte, length)
+	err := readRange(d.ctx, file, start, data)
+	for attempt := 0; err != nil && attempt < d.retries; attempt++ {
+		select {
+		case <-d.ctx.Done():
+			return d.ctx.Err()
+		case <-time.After(d.backoff << attempt):
+		}
+		err = readRange(d.ctx, file, start, data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download bytes %d-%d of %s: %w", start, start+length-1, file.Path, err)
+	}
+	_, err = w.WriteAt(data, start)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for d.next != part && d.ctx.Err() == nil {
+		d.hashed.Wait()
+	}
+	if d.ctx.Err() != nil {
+		return d.ctx.Err()
+	}
+	_, _ = d.hash.Write(data)
+	d.next++
+	d.hashed.Broadcast()
+	return nil
+}
+
+// readRange reads len(data) bytes of the file from start into data
+func readRange(ctx context.Context, file *Object, start int64, data []byte) error {
+	body, err := file.GetRange(ctx, start, int64(len(data)))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	_, err = io.ReadFull(body, data)
+	return err
+}
@@ -0,0 +1,84 @@
+package egnyte
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// partsFile serves the metadata of a file and its content with support for
+// Range requests. The first request for each range in failRanges fails
+type partsFile struct {
+	mutex      sync.Mutex
+	content    []byte
+	checksum   string
+	failRanges map[string]bool
+}
+
+func (f *partsFile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/pubapi/v1/fs-content/") {
+		_ = json.NewEncoder(w).Encode(&Object{
+			Size:            len(f.content),
+			Checksum:        f.checksum,
+			LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT",
+		})
+		return
+	}
+	f.mutex.Lock()
+	fail := f.failRanges[r.Header.Get("Range")]
+	delete(f.failRanges, r.Header.Get("Range"))
+	f.mutex.Unlock()
+	if fail {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(f.content))
+}
+
+// Test DownloadParallel retries failed ranges and verifies the checksum
+func TestDownloadParallel(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	server := &partsFile{
+		content:    content,
+		checksum:   SHA512Digest(content),
+		failRanges: map[string]bool{"bytes=1024-2047": true, "bytes=9216-9999": true},
+	}
+	client := newTestClient(t, server)
+	localFile, err := os.Create(filepath.Join(t.TempDir(), "file"))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer localFile.Close()
+	pdOpts := &ParallelDownloadOptions{Workers: 3, PartSize: 1024, RetryDelay: time.Millisecond}
+	err = client.Object("/Shared/file").DownloadParallel(context.Background(), localFile, pdOpts)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	data, _ := ioutil.ReadFile(localFile.Name())
+	if !bytes.Equal(data, content) {
+		t.Errorf("downloaded %d bytes", len(data))
+	}
+
+	server.failRanges["bytes=2048-3071"] = true
+	pdOpts.Retries = -1
+	err = client.Object("/Shared/file").DownloadParallel(context.Background(), localFile, pdOpts)
+	if !isStatus(err, http.StatusBadGateway) {
+		t.Errorf("%v", err)
+	}
+
+	pdOpts.Retries = 0
+	server.checksum = SHA512Digest([]byte("something else"))
+	err = client.Object("/Shared/file").DownloadParallel(context.Background(), localFile, pdOpts)
+	if !errors.Is(err, ChecksumMismatch) {
+		t.Errorf("%v", err)
+	}
+}
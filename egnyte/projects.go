@@ -0,0 +1,135 @@
+package egnyte
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+)
+
+// Egnyte Project Folder APIs
+
+var ProjectNameRequired = errors.New("project name required")
+
+// newProjectRequest returns the request payload setting the non zero options
+func newProjectRequest(options *ProjectOptions) *projectRequest {
+	req := &projectRequest{}
+	if options == nil {
+		return req
+	}
+	req.Name = options.Name
+	req.ProjectID = options.ProjectID
+	req.CustomerName = options.CustomerName
+	req.Status = options.Status
+	req.Description = options.Description
+	if !options.StartDate.IsZero() {
+		req.StartDate = options.StartDate.UTC().Format(time.RFC3339)
+	}
+	if !options.CompletionDate.IsZero() {
+		req.CompletionDate = options.CompletionDate.UTC().Format(time.RFC3339)
+	}
+	return req
+}
+
+// MarkAsProject marks the folder as a project with the given properties
+func (o *Object) MarkAsProject(ctx context.Context, options *ProjectOptions) (*Project, error) {
+	id, err := o.folderID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := newProjectRequest(options)
+	req.RootFolderID = id
+	if req.Name == "" {
+		req.Name = path.Base(o.Path)
+	}
+	return o.Client.createProject(ctx, URI_PROJECT_FOLDERS, req)
+}
+
+// CreateProjectFromTemplate creates a project folder named options.Name in
+// the parent folder with a copy of the structure of the template folder
+func (o *Object) CreateProjectFromTemplate(ctx context.Context, parent *Object, options *ProjectOptions) (*Project, error) {
+	req := newProjectRequest(options)
+	if req.Name == "" {
+		return nil, ProjectNameRequired
+	}
+	templateID, err := o.folderID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := parent.folderID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.TemplateFolderID = templateID
+	req.ParentFolderID = parentID
+	return o.Client.createProject(ctx, URI_PROJECT_TEMPLATE, req)
+}
+
+// createProject posts req to uri and returns the created project
+func (c *Client) createProject(ctx context.Context, uri string, req *projectRequest) (*Project, error) {
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   uri,
+	}
+	var projectResp *projectResponse
+	_, err := c.doRequest(ctx, opts, req, &projectResp)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetProject(ctx, projectResp.ID)
+}
+
+// GetProject returns the project with the given ID
+func (c *Client) GetProject(ctx context.Context, id string) (*Project, error) {
+	opts := &requestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf(URI_PROJECT_FOLDER, url.PathEscape(id)),
+	}
+	var project *Project
+	_, err := c.doRequest(ctx, opts, nil, &project)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// ListProjects lists all the project folders of the domain
+func (c *Client) ListProjects(ctx context.Context) ([]*Project, error) {
+	opts := &requestOptions{
+		Method: "GET",
+		Path:   URI_PROJECT_FOLDERS,
+	}
+	var projects []*Project
+	_, err := c.doRequest(ctx, opts, nil, &projects)
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// UpdateProject changes the non zero properties of the project with the given
+// ID and returns the updated project
+func (c *Client) UpdateProject(ctx context.Context, id string, options *ProjectOptions) (*Project, error) {
+	opts := &requestOptions{
+		Method: "PATCH",
+		Path:   fmt.Sprintf(URI_PROJECT_FOLDER, url.PathEscape(id)),
+	}
+	_, err := c.doRequest(ctx, opts, newProjectRequest(options), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetProject(ctx, id)
+}
+
+// UnmarkProject turns the project with the given ID back into a regular
+// folder. The folder and its content are kept
+func (c *Client) UnmarkProject(ctx context.Context, id string) error {
+	opts := &requestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf(URI_PROJECT_FOLDER, url.PathEscape(id)),
+	}
+	_, err := c.doRequest(ctx, opts, nil, nil)
+	return err
+}
@@ -0,0 +1,60 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Test MarkAsProject looks up the folder ID and returns the created project
+func TestMarkAsProject(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == fmt.Sprintf(URI_LIST, "/Shared/Acme"):
+			_ = json.NewEncoder(w).Encode(&Object{Path: "/Shared/Acme", IsFolder: true, FolderID: "f1"})
+		case r.Method == "POST" && r.URL.Path == URI_PROJECT_FOLDERS:
+			var req projectRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("%s", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if req.RootFolderID != "f1" || req.Name != "Acme" || req.CustomerName != "Acme Corp" ||
+				req.Status != ProjectInProgress || req.StartDate != "2024-02-01T00:00:00Z" || req.CompletionDate != "" {
+				t.Errorf("unexpected request %+v", req)
+			}
+			_ = json.NewEncoder(w).Encode(&projectResponse{ID: "p1"})
+		case r.Method == "GET" && r.URL.Path == fmt.Sprintf(URI_PROJECT_FOLDER, "p1"):
+			_, _ = w.Write([]byte(`{"id":"p1","rootFolderId":"f1","name":"Acme","customerName":"Acme Corp",` +
+				`"status":"in-progress","startDate":"2024-02-01T00:00:00Z"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	project, err := client.Object("/Shared/Acme").MarkAsProject(context.Background(), &ProjectOptions{
+		CustomerName: "Acme Corp",
+		Status:       ProjectInProgress,
+		StartDate:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if project.ID != "p1" || project.RootFolderID != "f1" || project.StartDate == nil || project.CompletionDate != nil {
+		t.Errorf("unexpected project %+v", project)
+	}
+}
+
+// Test CreateProjectFromTemplate requires a name
+func TestCreateProjectFromTemplateName(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	template := client.Object("/Shared/Templates/Project")
+	_, err := template.CreateProjectFromTemplate(context.Background(), client.Object("/Shared"), nil)
+	if err != ProjectNameRequired {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package egnyte
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+const (
+	defaultBlockSize       = 256 * 1024
+	defaultCacheBlocks     = 16
+	defaultReadAheadBlocks = 1
+)
+
+// RemoteReader provides random access to an Egnyte file without downloading
+// all of it. It implements io.ReaderAt and io.ReadSeekCloser. Reads are served
+// from a cache of fixed size blocks which are fetched with ranged requests,
+// together with the blocks following them
+type RemoteReader struct {
+	ctx       context.Context
+	obj       *Object
+	size      int64
+	offset    int64 // Position of Read and Seek
+	blockSize int64
+	maxBlocks int
+	readAhead int
+
+	mutex  sync.Mutex
+	blocks map[int64]*list.Element // Block index to its element in lru
+	lru    *list.List              // Cached blocks, most recently used first
+}
+
+// cachedBlock is a block of a file held in the cache of a RemoteReader
+type cachedBlock struct {
+	index int64
+	data  []byte
+}
+
+// OpenReader returns a RemoteReader over the file. The version of the file
+// at the time it is opened is read even if the file is updated meanwhile
+func (o *Object) OpenReader(ctx context.Context, readerOpts *ReaderOptions) (*RemoteReader, error) {
+	if readerOpts == nil {
+		readerOpts = &ReaderOptions{}
+	}
+	file, err := o.stat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if file.IsFolder {
+		return nil, FileRequired
+	}
+	r := &RemoteReader{
+		ctx:       ctx,
+		obj:       &Object{Client: o.Client, Path: o.Path, EntryID: file.EntryID},
+		size:      int64(file.Size),
+		blockSize: readerOpts.BlockSize,
+		maxBlocks: readerOpts.CacheBlocks,
+		readAhead: readerOpts.ReadAheadBlocks,
+		blocks:    map[int64]*list.Element{},
+		lru:       list.New(),
+	}
+	if r.blockSize <= 0 {
+		r.blockSize = defaultBlockSize
+	}
+	if r.maxBlocks <= 0 {
+		r.maxBlocks = defaultCacheBlocks
+	}
+	if r.readAhead <= 0 {
+		r.readAhead = defaultReadAheadBlocks
+	}
+	if r.readAhead >= r.maxBlocks {
+		r.readAhead = r.maxBlocks - 1
+	}
+	return r, nil
+}
+
+// Size returns the size of the file
+func (r *RemoteReader) Size() int64 {
+	return r.size
+}
+
+// block returns the data of the block with the given index, fetching it and
+// the blocks after it if it is not cached. Must be called with mutex held
+func (r *RemoteReader) block(index int64) ([]byte, error) {
+	if elem, ok := r.blocks[index]; ok {
+		r.lru.MoveToFront(elem)
+		return elem.Value.(*cachedBlock).data, nil
+	}
+	lastBlock := (r.size - 1) / r.blockSize
+	count := int64(1)
+	for count <= int64(r.readAhead) && index+count <= lastBlock {
+		if _, ok := r.blocks[index+count]; ok {
+			break
+		}
+		count++
+	}
+	start := index * r.blockSize
+	end := (index + count) * r.blockSize
+	if end > r.size {
+		end = r.size
+	}
+	body, err := r.obj.GetRange(r.ctx, start, end-start)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data := make([]byte, end-start)
+	_, err = io.ReadFull(body, data)
+	if err != nil {
+		return nil, err
+	}
+	// Cache the read ahead blocks first so that the requested one is the most
+	// recently used
+	for i := count - 1; i >= 0; i-- {
+		blockEnd := (i + 1) * r.blockSize
+		if blockEnd > int64(len(data)) {
+			blockEnd = int64(len(data))
+		}
+		r.blocks[index+i] = r.lru.PushFront(&cachedBlock{index: index + i, data: data[i*r.blockSize : blockEnd]})
+	}
+	for r.lru.Len() > r.maxBlocks {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.blocks, oldest.Value.(*cachedBlock).index)
+	}
+	return r.blocks[index].Value.(*cachedBlock).data, nil
+}
+
+// ReadAt reads len(p) bytes of the file starting at off. It is safe to call
+// ReadAt concurrently
+func (r *RemoteReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= r.size {
+			return n, io.EOF
+		}
+		data, err := r.block(pos / r.blockSize)
+		if err != nil {
+			return n, err
+		}
+		n += copy(p[n:], data[pos%r.blockSize:])
+	}
+	return n, nil
+}
+
+func (r *RemoteReader) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.offset)
+	r.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (r *RemoteReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return r.offset, errors.New("seek to a negative position")
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+// Close drops the cached blocks
+func (r *RemoteReader) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.blocks = map[int64]*list.Element{}
+	r.lru.Init()
+	return nil
+}
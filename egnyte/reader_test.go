@@ -0,0 +1,96 @@
+package egnyte
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rangedFile serves the metadata of a file and its content with support for
+// Range requests, counting the content requests
+type rangedFile struct {
+	mutex    sync.Mutex
+	content  []byte
+	requests int
+}
+
+func (f *rangedFile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/pubapi/v1/fs-content/") {
+		_ = json.NewEncoder(w).Encode(&Object{
+			Size:            len(f.content),
+			EntryID:         "entry-1",
+			LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT",
+		})
+		return
+	}
+	f.mutex.Lock()
+	f.requests++
+	f.mutex.Unlock()
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(f.content))
+}
+
+// Test RemoteReader can be used to read a zip archive in place
+func TestRemoteReaderZip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+	for _, name := range []string{"a.txt", "b.txt"} {
+		w, _ := archive.Create(name)
+		_, _ = w.Write(bytes.Repeat([]byte(name), 20000))
+	}
+	archive.Close()
+
+	server := &rangedFile{content: buf.Bytes()}
+	client := newTestClient(t, server)
+	reader, err := client.Object("/Shared/archive.zip").OpenReader(context.Background(), &ReaderOptions{BlockSize: 4096})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer reader.Close()
+	zipReader, err := zip.NewReader(reader, reader.Size())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(zipReader.File) != 2 || zipReader.File[1].Name != "b.txt" {
+		t.Errorf("%+v", zipReader.File)
+	}
+	if server.requests >= 3 {
+		t.Errorf("%d requests to read the central directory", server.requests)
+	}
+}
+
+// Test RemoteReader Read and Seek
+func TestRemoteReaderSeek(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+	server := &rangedFile{content: content}
+	client := newTestClient(t, server)
+	reader, err := client.Object("/Shared/file.txt").OpenReader(context.Background(), &ReaderOptions{BlockSize: 4, ReadAheadBlocks: 2})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	_, _ = reader.Seek(-5, io.SeekEnd)
+	tail, _ := ioutil.ReadAll(reader)
+	if string(tail) != "fghij" {
+		t.Errorf("%q", tail)
+	}
+	_, _ = reader.Seek(3, io.SeekStart)
+	all, _ := ioutil.ReadAll(reader)
+	if string(all) != string(content[3:]) {
+		t.Errorf("%q", all)
+	}
+	p := make([]byte, 3)
+	n, err := reader.ReadAt(p, 18)
+	if n != 2 || err != io.EOF {
+		t.Errorf("%d, %v", n, err)
+	}
+	if server.requests > 3 {
+		t.Errorf("%d requests", server.requests)
+	}
+}
@@ -0,0 +1,158 @@
+package egnyte
+
+import (
+	"context"
+	"time"
+)
+
+// Egnyte Search APIs
+
+// searchPageSize is the number of results requested per page by a search
+const searchPageSize = 100
+
+// SearchQuery builds the conditions of a search. The methods can be chained:
+//
+//	query := egnyte.NewSearchQuery("invoice").InFolder("/Shared/Finance").OfType(egnyte.SearchFiles)
+type SearchQuery struct {
+	req searchRequest
+}
+
+// NewSearchQuery returns a query for objects matching the full text query
+func NewSearchQuery(query string) *SearchQuery {
+	return &SearchQuery{req: searchRequest{Query: query}}
+}
+
+// InFolder limits the search to the folder at path and its subfolders
+func (q *SearchQuery) InFolder(path string) *SearchQuery {
+	q.req.Folder = path
+	return q
+}
+
+// OfType limits the search to files or folders
+func (q *SearchQuery) OfType(searchType SearchType) *SearchQuery {
+	q.req.Type = searchType
+	return q
+}
+
+// ModifiedBefore limits the search to objects last modified before t
+func (q *SearchQuery) ModifiedBefore(t time.Time) *SearchQuery {
+	q.req.ModifiedBefore = t.UTC().Format(time.RFC3339)
+	return q
+}
+
+// ModifiedAfter limits the search to objects last modified after t
+func (q *SearchQuery) ModifiedAfter(t time.Time) *SearchQuery {
+	q.req.ModifiedAfter = t.UTC().Format(time.RFC3339)
+	return q
+}
+
+// UploadedBy limits the search to files uploaded by the user with username
+func (q *SearchQuery) UploadedBy(username string) *SearchQuery {
+	q.req.UploadedBy = username
+	return q
+}
+
+// WithMetadata limits the search to objects on which the key of the custom
+// metadata namespace has value. Conditions on several keys must all match
+func (q *SearchQuery) WithMetadata(namespace, key string, value interface{}) *SearchQuery {
+	q.req.CustomMetadata = append(q.req.CustomMetadata, &MetadataCondition{
+		Namespace: namespace,
+		Key:       key,
+		Value:     value,
+	})
+	return q
+}
+
+// SearchIterator iterates over the results of a search, requesting the pages
+// of results as they are needed
+//
+//	it := client.Search(ctx, query)
+//	for it.Next() {
+//		obj := it.Object()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type SearchIterator struct {
+	ctx     context.Context
+	client  *Client
+	req     searchRequest
+	page    []*Object
+	current *Object
+	done    bool
+	err     error
+}
+
+// Search returns an iterator over the objects matching the query
+func (c *Client) Search(ctx context.Context, query *SearchQuery) *SearchIterator {
+	req := query.req
+	req.Count = searchPageSize
+	return &SearchIterator{
+		ctx:    ctx,
+		client: c,
+		req:    req,
+	}
+}
+
+// Next advances to the next result and returns false once there are no more
+// results, an error occurred or the context is done
+func (it *SearchIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	if len(it.page) == 0 && !it.done {
+		it.err = it.fetch()
+		if it.err != nil {
+			return false
+		}
+	}
+	if len(it.page) == 0 {
+		return false
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Object returns the current result
+func (it *SearchIterator) Object() *Object {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// fetch requests the next page of results
+func (it *SearchIterator) fetch() error {
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   URI_SEARCH,
+	}
+	var searchResp *searchResponse
+	_, err := it.client.doRequest(it.ctx, opts, &it.req, &searchResp)
+	if err != nil {
+		return err
+	}
+	for _, result := range searchResp.Results {
+		it.page = append(it.page, &Object{
+			Client:      it.client,
+			Name:        result.Name,
+			Path:        result.Path,
+			IsFolder:    result.Type == string(SearchFolders),
+			Size:        result.Size,
+			EntryID:     result.EntryID,
+			GroupID:     result.GroupID,
+			FolderID:    result.FolderID,
+			ModTime:     result.LastModified,
+			UploadedBy:  result.UploadedBy,
+			NumVersions: result.NumVersions,
+		})
+	}
+	it.req.Offset += len(searchResp.Results)
+	it.done = !searchResp.HasMore || len(searchResp.Results) == 0 || it.req.Offset >= searchResp.TotalCount
+	return nil
+}
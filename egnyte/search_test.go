@@ -0,0 +1,79 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Test Search sends the query conditions and pages through the results
+func TestSearch(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req searchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("%s", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Query != "invoice" || req.Folder != "/Shared/Finance" || req.Type != SearchFiles ||
+			req.ModifiedAfter != "2024-01-01T00:00:00Z" || len(req.CustomMetadata) != 1 ||
+			req.CustomMetadata[0].Key != "status" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		resp := &searchResponse{TotalCount: 250}
+		for i := req.Offset; i < req.Offset+req.Count && i < resp.TotalCount; i++ {
+			resp.Results = append(resp.Results, &searchResult{
+				Name: fmt.Sprintf("%d.pdf", i),
+				Path: fmt.Sprintf("/Shared/Finance/%d.pdf", i),
+				Type: "FILE",
+			})
+		}
+		resp.HasMore = req.Offset+len(resp.Results) < resp.TotalCount
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	query := NewSearchQuery("invoice").
+		InFolder("/Shared/Finance").
+		OfType(SearchFiles).
+		ModifiedAfter(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+		WithMetadata("contracts", "status", "signed")
+	it := client.Search(context.Background(), query)
+	count := 0
+	for it.Next() {
+		if obj := it.Object(); obj.Name != fmt.Sprintf("%d.pdf", count) || obj.IsFolder || obj.Client != client {
+			t.Errorf("unexpected result %+v", obj)
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if count != 250 {
+		t.Errorf("%d results", count)
+	}
+}
+
+// Test Search stops once the context is canceled
+func TestSearchCanceled(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := &searchResponse{TotalCount: 1000, HasMore: true}
+		for i := 0; i < searchPageSize; i++ {
+			resp.Results = append(resp.Results, &searchResult{Name: "a", Type: "FILE"})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.Search(ctx, NewSearchQuery("a"))
+	if !it.Next() {
+		t.Fatalf("%s", it.Err())
+	}
+	cancel()
+	if it.Next() {
+		t.Errorf("Next succeeded after cancel")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("unexpected error %v", it.Err())
+	}
+}
@@ -0,0 +1,63 @@
+package egnyte
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// statsWorkers is the number of folders listed concurrently when folder stats
+// are computed on the client
+const statsWorkers = 8
+
+// Stats fetches the size and item counts (for both file versions and files)
+// of the folder and all its subfolders. Domains that do not provide the stats
+// API get the same numbers by listing the whole folder tree
+func (o *Object) Stats(ctx context.Context) (*FolderStats, error) {
+	folderID, err := o.folderID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uri := fmt.Sprintf(URI_FOLDER_STATS, folderID)
+	opts := &requestOptions{
+		Method: "GET",
+		Path:   uri,
+	}
+	var stats *FolderStats
+	_, err = o.Client.doRequest(ctx, opts, nil, &stats)
+	if isStatus(err, http.StatusNotFound) || isStatus(err, http.StatusNotImplemented) {
+		return o.walkStats(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// walkStats computes the folder stats by walking the folder tree with
+// statsWorkers concurrent listings
+func (o *Object) walkStats(ctx context.Context) (*FolderStats, error) {
+	stats := &FolderStats{}
+	// Walk calls the function for the root folder first, which is not counted
+	isRoot := true
+	err := Walk(ctx, o, func(p string, obj *Object, err error) error {
+		if err != nil {
+			return err
+		}
+		switch {
+		case isRoot:
+			isRoot = false
+		case obj.IsFolder:
+			stats.Folders++
+		default:
+			stats.Files++
+			stats.Size += int64(obj.Size)
+			stats.Versions += int64(obj.NumVersions)
+		}
+		return nil
+	}, &WalkOptions{Workers: statsWorkers})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
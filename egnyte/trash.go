@@ -0,0 +1,91 @@
+package egnyte
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Egnyte Trash APIs
+
+// ListTrashPage lists a page of at most count deleted items starting at offset
+// and returns them with the total number of items matching the filter
+func (c *Client) ListTrashPage(ctx context.Context, filter *TrashFilter, offset, count int) ([]*TrashItem, int, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("count", strconv.Itoa(count))
+	if filter != nil {
+		if filter.PathPrefix != "" {
+			params.Set("path", filter.PathPrefix)
+		}
+		if filter.DeletedBy != "" {
+			params.Set("deleted_by", filter.DeletedBy)
+		}
+		if !filter.DeletedAfter.IsZero() {
+			params.Set("deleted_after", filter.DeletedAfter.UTC().Format(time.RFC3339))
+		}
+		if !filter.DeletedBefore.IsZero() {
+			params.Set("deleted_before", filter.DeletedBefore.UTC().Format(time.RFC3339))
+		}
+	}
+	opts := &requestOptions{
+		Method:     "GET",
+		Path:       URI_TRASH,
+		Parameters: params,
+	}
+	var trashResp *listTrashResponse
+	_, err := c.doRequest(ctx, opts, nil, &trashResp)
+	if err != nil {
+		return nil, 0, err
+	}
+	return trashResp.Items, trashResp.TotalCount, nil
+}
+
+// ListTrash lists the deleted items matching the filter
+// Returns all items by iterating through all available pages
+func (c *Client) ListTrash(ctx context.Context, filter *TrashFilter) ([]*TrashItem, error) {
+	var items []*TrashItem
+	offset := 0
+	itemsPerPage := 100
+	for {
+		page, total, err := c.ListTrashPage(ctx, filter, offset, itemsPerPage)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		offset += len(page)
+		if len(page) == 0 || offset >= total {
+			break
+		}
+	}
+	return items, nil
+}
+
+// RestoreTrash restores the deleted items with the given IDs to their original
+// location, or into the folder at destination if it is not empty
+func (c *Client) RestoreTrash(ctx context.Context, ids []string, destination string) error {
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   URI_TRASH_RESTORE,
+	}
+	req := &trashItemsRequest{
+		IDs:         ids,
+		Destination: destination,
+	}
+	_, err := c.doRequest(ctx, opts, req, nil)
+	return err
+}
+
+// PurgeTrash permanently deletes the items with the given IDs from the trash
+func (c *Client) PurgeTrash(ctx context.Context, ids []string) error {
+	opts := &requestOptions{
+		Method: "POST",
+		Path:   URI_TRASH_PURGE,
+	}
+	req := &trashItemsRequest{
+		IDs: ids,
+	}
+	_, err := c.doRequest(ctx, opts, req, nil)
+	return err
+}
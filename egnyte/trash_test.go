@@ -0,0 +1,38 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Test ListTrash pages through all the items matching the filter
+func TestListTrash(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("path") != "/Shared/Projects" || query.Get("deleted_after") != "2024-01-01T00:00:00Z" {
+			t.Errorf("unexpected filter %v", query)
+		}
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		resp := &listTrashResponse{Offset: offset, TotalCount: 150}
+		for i := offset; i < offset+100 && i < resp.TotalCount; i++ {
+			resp.Items = append(resp.Items, &TrashItem{ID: fmt.Sprintf("%d", i)})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	filter := &TrashFilter{
+		PathPrefix:   "/Shared/Projects",
+		DeletedAfter: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	items, err := client.ListTrash(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(items) != 150 || items[149].ID != "149" {
+		t.Errorf("%d items", len(items))
+	}
+}
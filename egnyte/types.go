@@ -1,6 +1,7 @@
 package egnyte
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -69,7 +70,10 @@ type Object struct {
 	Folders            []*Object `json:"folders"`
 	Files              []*Object `json:"files"`
 	Versions           []*Object `json:"versions"`
-	Err                error     // This is used to store the error for this file in case of batch upload
+	// Custom metadata values by namespace and key, only set by listings
+	// requested with ListOptions.IncludeMetadata
+	CustomMetadata map[string]map[string]interface{} `json:"custom_metadata"`
+	Err            error                             // This is used to store the error for this file in case of batch upload
 }
 
 // UserName is used to represent the family name and given name if a user
@@ -212,6 +216,25 @@ func (e *Error) Timeout() bool {
 	return false
 }
 
+var ChecksumMismatch = errors.New("checksum mismatch")
+
+// IntegrityError is returned when the SHA-512 of the content of a file that
+// was transferred does not match the checksum of the file on the server.
+// It matches ChecksumMismatch with errors.Is
+type IntegrityError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %s: expected %s, got %s", e.Path, e.Expected, e.Actual)
+}
+
+func (e *IntegrityError) Unwrap() error {
+	return ChecksumMismatch
+}
+
 // errorReply is the response received from Egnyte in case of an error
 type errorReply struct {
 	ResponseCode string `json:"responseCode"`
@@ -247,6 +270,518 @@ type createFolderRequest struct {
 	Action string `json:"action"`
 }
 
+// fsActionRequest is the request for the actions (move, copy etc.) that are
+// performed on a file or folder using the fs API
+type fsActionRequest struct {
+	Action          string `json:"action"`
+	Destination     string `json:"destination,omitempty"`
+	PreserveModTime bool   `json:"preserve_modified_time,omitempty"`
+	LockToken       string `json:"lock_token,omitempty"`
+	LockTimeout     int64  `json:"lock_timeout,omitempty"` // In seconds
+}
+
+// ConflictPolicy decides what happens when the destination of a move or copy
+// already exists
+type ConflictPolicy int
+
+const (
+	ConflictFail ConflictPolicy = iota // Return DestinationExists and leave both objects untouched
+	// Delete the existing destination file and retry. This is not atomic:
+	// the destination is gone if the retry fails. Folders, destinations of
+	// another type and destinations containing the source are never
+	// overwritten, OverwriteRefused is returned instead
+	ConflictOverwrite
+	ConflictRename // Use the first free name of the form "name (n).ext"
+)
+
+// MoveOptions are the optional settings for Object.Move
+type MoveOptions struct {
+	OnConflict ConflictPolicy
+}
+
+// CopyProgress is reported to CopyOptions.Progress after each file of a folder
+// copy
+type CopyProgress struct {
+	Path        string // Source path of the file that was copied last
+	FilesCopied int
+	TotalFiles  int
+	BytesCopied int64
+	TotalBytes  int64
+}
+
+// CopyOptions are the optional settings for Object.Copy
+type CopyOptions struct {
+	OnConflict      ConflictPolicy
+	PreserveModTime bool               // Keep the modification time of the source files
+	Progress        func(CopyProgress) // Called after each file when copying a folder
+}
+
+// FolderStats is the size and item counts of a folder and all its subfolders
+type FolderStats struct {
+	Size     int64 `json:"filesSize"`    // Total size of the current versions of the files
+	Files    int64 `json:"allFiles"`     // Number of files
+	Folders  int64 `json:"folders"`      // Number of subfolders, not counting the folder itself
+	Versions int64 `json:"fileVersions"` // Number of file versions, including current ones
+}
+
+// FileLock is a lock held on a file
+type FileLock struct {
+	Path    string
+	Token   string        // Needed to renew or release the lock
+	Timeout time.Duration // Zero if the lock uses the server default timeout
+	Expires time.Time     // Zero if the lock uses the server default timeout
+}
+
+// SortBy is the field the children of a folder are sorted on when listing
+type SortBy string
+
+const (
+	SortByName         SortBy = "name"
+	SortBySize         SortBy = "size"
+	SortByLastModified SortBy = "last_modified"
+)
+
+// SortDirection is the direction the children of a folder are sorted in
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "ascending"
+	SortDescending SortDirection = "descending"
+)
+
+// ListFilter selects the kind of children returned when listing a folder
+type ListFilter int
+
+const (
+	ListAllItems ListFilter = iota
+	ListFilesOnly
+	ListFoldersOnly
+)
+
+// ListOptions are the optional settings for listing a folder
+type ListOptions struct {
+	Offset        int // Index of the first child to list
+	Count         int // Number of children per page, server default if zero
+	SortBy        SortBy
+	SortDirection SortDirection
+	Filter        ListFilter
+	// Include the custom metadata of the children in Object.CustomMetadata
+	IncludeMetadata bool
+}
+
+// ReaderOptions are the optional settings for Object.OpenReader
+type ReaderOptions struct {
+	BlockSize       int64 // Size of the blocks fetched and cached, defaults to 256 KiB
+	CacheBlocks     int   // Number of blocks kept in the cache, defaults to 16
+	ReadAheadBlocks int   // Number of blocks fetched after the one being read, defaults to 1
+}
+
+// ParallelDownloadOptions are the optional settings for Object.DownloadParallel
+type ParallelDownloadOptions struct {
+	Workers    int           // Number of ranges downloaded concurrently, defaults to 4
+	PartSize   int64         // Size of the ranges, defaults to 8 MiB
+	Retries    int           // Number of times a failed range is retried, defaults to 3, negative for none
+	RetryDelay time.Duration // Wait before the first retry of a range, doubled on every retry, defaults to half a second
+}
+
+// UploadOptions are the optional settings for Client.Upload
+type UploadOptions struct {
+	ModTime        time.Time   // Modification time of the file, defaults to now
+	ChunkThreshold int64       // Files larger than this are uploaded in chunks, defaults to 100 MiB
+	ChunkSize      int64       // Size of the chunks, defaults to 32 MiB
+	Workers        int         // Number of chunks uploaded concurrently, defaults to 4
+	BufferPool     *BufferPool // Pool of the chunk buffers, defaults to DefaultBufferPool
+	// Compute the SHA-512 of the content while uploading it and compare it
+	// with the checksum of the file on the server
+	VerifyChecksum bool
+	// Chunked uploads are recorded in StateStore, if set, so that an upload
+	// interrupted by a crash or an error can be resumed by calling Upload again
+	// with the same content. If the server rejects the upload ID of a resumed
+	// session, the session is deleted and the upload restarts from the first
+	// chunk when the reader is an io.Seeker; otherwise UploadSessionExpired is
+	// returned and the next call starts over
+	StateStore UploadStateStore
+}
+
+// UploadSession is the state of a chunked upload as persisted by an
+// UploadStateStore
+type UploadSession struct {
+	UploadID  string         `json:"upload_id"`
+	Path      string         `json:"path"`
+	Size      int64          `json:"size"`
+	ChunkSize int64          `json:"chunk_size"`
+	ModTime   time.Time      `json:"mod_time"`
+	Chunks    map[int]string `json:"chunks"` // Checksums of the uploaded chunks by chunk number
+}
+
+// UploadStateStore persists chunked upload sessions by the path of the file
+// being uploaded. Load returns nil without an error if there is no session
+type UploadStateStore interface {
+	Load(path string) (*UploadSession, error)
+	Save(session *UploadSession) error
+	Delete(path string) error
+}
+
+// FileVersion is a version of a file
+type FileVersion struct {
+	EntryID    string
+	Size       int64
+	UploadedBy string
+	ModTime    time.Time
+	Checksum   string
+	Current    bool // Whether this is the current version of the file
+}
+
+// TrashItem is a deleted file or folder in the trash
+type TrashItem struct {
+	ID        string    `json:"id"`
+	Path      string    `json:"path"` // Original path of the item
+	Name      string    `json:"name"`
+	IsFolder  bool      `json:"is_folder"`
+	Size      int64     `json:"size"`
+	DeletedBy string    `json:"deleted_by"`
+	DeletedAt time.Time `json:"deleted_date"`
+}
+
+// TrashFilter selects the items listed from the trash. Zero fields are not
+// used for filtering
+type TrashFilter struct {
+	PathPrefix    string // Only items originally under this path
+	DeletedBy     string // Username of the user who deleted the items
+	DeletedAfter  time.Time
+	DeletedBefore time.Time
+}
+
+// listTrashResponse is the response payload of the list trash API
+type listTrashResponse struct {
+	Offset     int          `json:"offset"`
+	Count      int          `json:"count"`
+	TotalCount int          `json:"total_count"`
+	Items      []*TrashItem `json:"items"`
+}
+
+// trashItemsRequest is the request payload of the restore and purge trash APIs
+type trashItemsRequest struct {
+	IDs         []string `json:"ids"`
+	Destination string   `json:"destination,omitempty"`
+}
+
+// LinkType is the kind of object a link gives access to
+type LinkType string
+
+const (
+	LinkFile   LinkType = "file"
+	LinkFolder LinkType = "folder"
+	LinkUpload LinkType = "upload"
+)
+
+// LinkAccessibility controls who can open a link
+type LinkAccessibility string
+
+const (
+	LinkAnyone     LinkAccessibility = "anyone"
+	LinkPassword   LinkAccessibility = "password"
+	LinkDomain     LinkAccessibility = "domain"
+	LinkRecipients LinkAccessibility = "recipients"
+)
+
+// LinkOptions are the settings of a new link. Zero and nil fields use the
+// domain defaults
+type LinkOptions struct {
+	Accessibility LinkAccessibility // Defaults to LinkAnyone
+	Password      string            // Password of a LinkPassword link, generated when empty
+	Recipients    []string          // Email addresses the link is for
+	SendEmail     *bool             // Email the link to the recipients
+	Message       string            // Message of the email sent to the recipients
+	CopyMe        *bool             // Send a copy of the email to the creator
+	Notify        *bool             // Notify the creator when the link is opened
+	LinkToCurrent bool              // File links only: always point to the current version of the file instead of the version at link creation
+	ExpiryDate    time.Time         // Day after which the link expires
+	ExpiryClicks  int               // Number of clicks after which the link expires
+}
+
+// createLinkRequest is the request payload of the create link API
+type createLinkRequest struct {
+	Path          string            `json:"path"`
+	Type          LinkType          `json:"type"`
+	Accessibility LinkAccessibility `json:"accessibility"`
+	Password      string            `json:"password,omitempty"`
+	Recipients    []string          `json:"recipients,omitempty"`
+	SendEmail     *bool             `json:"send_email,omitempty"`
+	Message       string            `json:"message,omitempty"`
+	CopyMe        *bool             `json:"copy_me,omitempty"`
+	Notify        *bool             `json:"notify,omitempty"`
+	LinkToCurrent bool              `json:"link_to_current,omitempty"`
+	ExpiryDate    string            `json:"expiry_date,omitempty"`
+	ExpiryClicks  int               `json:"expiry_clicks,omitempty"`
+}
+
+// createLinkResponse is the response payload of the create link API. One link
+// is created per recipient
+type createLinkResponse struct {
+	Link
+	Links []struct {
+		ID         string   `json:"id"`
+		URL        string   `json:"url"`
+		Recipients []string `json:"recipients"`
+	} `json:"links"`
+}
+
+// Link is a shared link to a file or folder
+type Link struct {
+	ID            string            `json:"id"`
+	URL           string            `json:"url"`
+	Path          string            `json:"path"`
+	Type          LinkType          `json:"type"`
+	Accessibility LinkAccessibility `json:"accessibility"`
+	Password      string            `json:"password"` // Only returned when the link is created
+	Recipients    []string          `json:"recipients"`
+	Notify        bool              `json:"notify"`
+	LinkToCurrent bool              `json:"link_to_current"`
+	ExpiryDate    string            `json:"expiry_date"` // Formatted as YYYY-MM-DD
+	ExpiryClicks  int               `json:"expiry_clicks"`
+	CreationDate  time.Time         `json:"creation_date"`
+	CreatedBy     string            `json:"created_by"`
+}
+
+// LinkFilter selects the links listed. Zero fields are not used for filtering
+type LinkFilter struct {
+	Path          string // Only links to this file or folder
+	Username      string // Username of the user who created the links
+	Type          LinkType
+	Accessibility LinkAccessibility
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// listLinksResponse is the response payload of the list links API
+type listLinksResponse struct {
+	Offset     int     `json:"offset"`
+	Count      int     `json:"count"`
+	TotalCount int     `json:"total_count"`
+	Links      []*Link `json:"links"`
+}
+
+// EmailPreferences selects the folder events members are notified of by email
+type EmailPreferences struct {
+	ContentUpdates  bool `json:"content_updates"`  // Files added or updated
+	ContentAccessed bool `json:"content_accessed"` // Files downloaded or previewed
+	Comments        bool `json:"comments"`         // Notes added to files
+	MovedDeleted    bool `json:"moved_deleted"`    // Files or folders moved or deleted
+}
+
+// FolderOptions are the settings of a folder
+type FolderOptions struct {
+	Description        string            `json:"folder_description"`
+	RestrictMoveDelete bool              `json:"restrict_move_delete"` // Only owners can move or delete the folder
+	EmailPreferences   *EmailPreferences `json:"email_preferences"`
+}
+
+// FolderOptionsUpdate holds the folder settings to change. Nil fields are left
+// unchanged
+type FolderOptionsUpdate struct {
+	Description        *string           `json:"folder_description,omitempty"`
+	RestrictMoveDelete *bool             `json:"restrict_move_delete,omitempty"`
+	EmailPreferences   *EmailPreferences `json:"email_preferences,omitempty"`
+}
+
+// ProjectStatus is the stage of a project
+type ProjectStatus string
+
+const (
+	ProjectPending    ProjectStatus = "pending"
+	ProjectInProgress ProjectStatus = "in-progress"
+	ProjectOnHold     ProjectStatus = "on-hold"
+	ProjectDone       ProjectStatus = "done"
+	ProjectCanceled   ProjectStatus = "canceled"
+)
+
+// ProjectOptions are the properties of a project folder. Zero fields are not
+// set, or left unchanged by an update
+type ProjectOptions struct {
+	Name           string // Defaults to the folder name when marking a folder
+	ProjectID      string // Identifier of the project in other systems
+	CustomerName   string
+	Status         ProjectStatus
+	StartDate      time.Time
+	CompletionDate time.Time
+	Description    string
+}
+
+// projectRequest is the request payload of the project folder APIs
+type projectRequest struct {
+	RootFolderID     string        `json:"rootFolderId,omitempty"`
+	ParentFolderID   string        `json:"parentFolderId,omitempty"`
+	TemplateFolderID string        `json:"templateFolderId,omitempty"`
+	Name             string        `json:"name,omitempty"`
+	ProjectID        string        `json:"projectId,omitempty"`
+	CustomerName     string        `json:"customerName,omitempty"`
+	Status           ProjectStatus `json:"status,omitempty"`
+	StartDate        string        `json:"startDate,omitempty"`
+	CompletionDate   string        `json:"completionDate,omitempty"`
+	Description      string        `json:"description,omitempty"`
+}
+
+// projectResponse is the response payload of the create project APIs
+type projectResponse struct {
+	ID string `json:"id"`
+}
+
+// Project is a folder marked as a project
+type Project struct {
+	ID               string        `json:"id"`
+	RootFolderID     string        `json:"rootFolderId"` // Folder ID of the project folder
+	Path             string        `json:"path"`
+	Name             string        `json:"name"`
+	ProjectID        string        `json:"projectId"`
+	CustomerName     string        `json:"customerName"`
+	Status           ProjectStatus `json:"status"`
+	StartDate        *time.Time    `json:"startDate"`
+	CompletionDate   *time.Time    `json:"completionDate"`
+	Description      string        `json:"description"`
+	CreatedBy        string        `json:"createdBy"`
+	CreationDate     time.Time     `json:"creationDate"`
+	LastModifiedBy   string        `json:"lastModifiedBy"`
+	LastModifiedDate time.Time     `json:"lastModifiedDate"`
+}
+
+// Bookmark is a folder bookmarked by the user
+type Bookmark struct {
+	ID           string    `json:"id"`
+	Path         string    `json:"path"`
+	FolderID     string    `json:"folder_id"`
+	CreationDate time.Time `json:"creation_date"`
+	Folder       *Object   `json:"-"` // The bookmarked folder
+}
+
+// bookmarkRequest is the request payload of the create bookmark API
+type bookmarkRequest struct {
+	Path string `json:"path"`
+}
+
+// listBookmarksResponse is the response payload of the list bookmarks API
+type listBookmarksResponse struct {
+	Offset     int         `json:"offset"`
+	Count      int         `json:"count"`
+	TotalCount int         `json:"total_count"`
+	Bookmarks  []*Bookmark `json:"bookmarks"`
+}
+
+// Note is a comment left on a file
+type Note struct {
+	ID            string    `json:"id"`
+	FileID        string    `json:"file_id"` // Group ID of the file
+	FilePath      string    `json:"file_path"`
+	Message       string    `json:"message"`
+	Username      string    `json:"username"`       // Username of the author
+	FormattedName string    `json:"formatted_name"` // Full name of the author
+	CreatedAt     time.Time `json:"creation_time"`
+	CanDelete     bool      `json:"can_delete"`
+}
+
+// addNoteRequest is the request payload of the add note API
+type addNoteRequest struct {
+	Path string `json:"path"`
+	Body string `json:"body"`
+}
+
+// addNoteResponse is the response payload of the add note API
+type addNoteResponse struct {
+	ID string `json:"id"`
+}
+
+// listNotesResponse is the response payload of the list notes API
+type listNotesResponse struct {
+	Offset       int     `json:"offset"`
+	Count        int     `json:"count"`
+	TotalResults int     `json:"total_results"`
+	Notes        []*Note `json:"notes"`
+}
+
+// MetadataKeyType is the type of the values of a custom metadata key
+type MetadataKeyType string
+
+const (
+	MetadataString  MetadataKeyType = "string"
+	MetadataInteger MetadataKeyType = "integer"
+	MetadataDecimal MetadataKeyType = "decimal"
+	MetadataDate    MetadataKeyType = "date"
+	MetadataEnum    MetadataKeyType = "enum"
+)
+
+// MetadataKey is a key of a custom metadata namespace
+type MetadataKey struct {
+	Type        MetadataKeyType `json:"type"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Priority    int             `json:"priority,omitempty"` // Position of the key when displayed
+	Values      []string        `json:"data,omitempty"`     // Allowed values of an enum key
+}
+
+// MetadataNamespace is a namespace of custom metadata keys that can be set on
+// files and folders
+type MetadataNamespace struct {
+	Name        string                  `json:"name"`
+	DisplayName string                  `json:"displayName,omitempty"`
+	Scope       string                  `json:"scope,omitempty"` // public, protected or private
+	Keys        map[string]*MetadataKey `json:"keys"`
+}
+
+// listNamespacesResponse is the response payload of the list metadata
+// namespaces API
+type listNamespacesResponse struct {
+	Namespaces []*MetadataNamespace `json:"namespaces"`
+}
+
+// SearchType restricts the kind of objects returned by a search
+type SearchType string
+
+const (
+	SearchAll     SearchType = "ALL"
+	SearchFiles   SearchType = "FILE"
+	SearchFolders SearchType = "FOLDER"
+)
+
+// MetadataCondition matches objects on which a custom metadata key has a value
+type MetadataCondition struct {
+	Namespace string      `json:"namespace"`
+	Key       string      `json:"key"`
+	Value     interface{} `json:"value"`
+}
+
+// searchRequest is the request payload of the search API
+type searchRequest struct {
+	Query          string               `json:"query,omitempty"`
+	Folder         string               `json:"folder,omitempty"`
+	Type           SearchType           `json:"type,omitempty"`
+	ModifiedBefore string               `json:"modified_before,omitempty"`
+	ModifiedAfter  string               `json:"modified_after,omitempty"`
+	UploadedBy     string               `json:"uploaded_by,omitempty"`
+	CustomMetadata []*MetadataCondition `json:"custom_metadata,omitempty"`
+	Offset         int                  `json:"offset"`
+	Count          int                  `json:"count"`
+}
+
+// searchResult is an object found by the search API
+type searchResult struct {
+	Name         string    `json:"name"`
+	Path         string    `json:"path"`
+	Type         string    `json:"type"`
+	Size         int       `json:"size"`
+	EntryID      string    `json:"entry_id"`
+	GroupID      string    `json:"group_id"`
+	FolderID     string    `json:"folder_id"`
+	LastModified time.Time `json:"last_modified"`
+	UploadedBy   string    `json:"uploaded_by"`
+	NumVersions  int       `json:"num_versions"`
+}
+
+// searchResponse is the response payload of the search API
+type searchResponse struct {
+	Results    []*searchResult `json:"results"`
+	TotalCount int             `json:"total_count"`
+	HasMore    bool            `json:"hasMore"`
+}
+
 type MultiPutFileContent struct {
 	LastModified   string `json:"lastModified"`
 	Content        string `json:"content"`
@@ -0,0 +1,268 @@
+package egnyte
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Chunked upload headers
+const (
+	headerUploadID      = "X-Egnyte-Upload-Id"
+	headerChunkNum      = "X-Egnyte-Chunk-Num"
+	headerLastChunk     = "X-Egnyte-Last-Chunk"
+	headerChunkChecksum = "X-Egnyte-Chunk-Sha512-Checksum"
+	headerChecksum      = "X-Sha512-Checksum"
+)
+
+const (
+	defaultChunkThreshold = 100 * 1024 * 1024
+	defaultChunkSize      = 32 * 1024 * 1024
+	defaultUploadWorkers  = 4
+)
+
+// Upload uploads size bytes read from reader to a file at path. Files up to
+// the chunk threshold are uploaded in a single request, larger files in chunks
+// which, apart from the first and the last one, are uploaded concurrently.
+// The checksum returned for the last chunk is compared with the SHA-512 of the
+// chunk checksums collected by ChunkUploadInfo
+func (c *Client) Upload(ctx context.Context, path string, reader io.Reader, size int64, upOpts *UploadOptions) (*Object, error) {
+	options := UploadOptions{}
+	if upOpts != nil {
+		options = *upOpts
+	}
+	if options.ModTime.IsZero() {
+		options.ModTime = time.Now()
+	}
+	if options.ChunkThreshold <= 0 {
+		options.ChunkThreshold = defaultChunkThreshold
+	}
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = defaultChunkSize
+	}
+	if options.Workers <= 0 {
+		options.Workers = defaultUploadWorkers
+	}
+	if options.BufferPool == nil {
+		options.BufferPool = DefaultBufferPool
+	}
+	// A seekable reader is rewound to restart a resumed upload whose session
+	// has expired on the server
+	seeker, _ := reader.(io.Seeker)
+	var start int64
+	if seeker != nil && options.StateStore != nil {
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			seeker = nil
+		}
+		start = offset
+	}
+	var digest hash.Hash
+	body := func() io.Reader {
+		if !options.VerifyChecksum {
+			return reader
+		}
+		digest = sha512.New()
+		return io.TeeReader(reader, digest)
+	}
+	obj := &Object{
+		Client:  c,
+		Path:    path,
+		Body:    body(),
+		Size:    int(size),
+		ModTime: options.ModTime,
+	}
+	uploaded, err := obj.upload(ctx, options)
+	if errors.Is(err, UploadSessionExpired) && seeker != nil {
+		// The session has been deleted, start over from the first chunk
+		if _, seekErr := seeker.Seek(start, io.SeekStart); seekErr != nil {
+			return nil, err
+		}
+		obj.Body = body()
+		uploaded, err = obj.upload(ctx, options)
+	}
+	if err != nil || digest == nil {
+		return uploaded, err
+	}
+
+	checksum := uploaded.Checksum
+	if options.chunked(size) {
+		// The checksum returned for a chunked upload is not the one of the file
+		file, err := obj.stat(ctx)
+		if err != nil {
+			return nil, err
+		}
+		checksum = file.Checksum
+	}
+	expected := hex.EncodeToString(digest.Sum(nil))
+	if checksum != expected {
+		return nil, &IntegrityError{Path: path, Expected: expected, Actual: checksum}
+	}
+	return uploaded, nil
+}
+
+// chunked checks if a file of size bytes is uploaded in chunks
+func (u UploadOptions) chunked(size int64) bool {
+	return size > u.ChunkThreshold && size > u.ChunkSize
+}
+
+// upload uploads the body of the object in a single request or in chunks
+func (o *Object) upload(ctx context.Context, options UploadOptions) (*Object, error) {
+	size := int64(o.Size)
+	if !options.chunked(size) {
+		return o.Create(ctx)
+	}
+	info := &ChunkUploadInfo{}
+	if options.StateStore == nil {
+		info.InitWithPool(o.Body, size, options.ChunkSize, options.BufferPool)
+		return o.uploadChunks(ctx, info, options.Workers, nil)
+	}
+	tracker, reader, err := resumeSession(options.StateStore, o, options.ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	info.InitWithPool(reader, size, options.ChunkSize, options.BufferPool)
+	info.Resume(tracker.chunkNum, tracker.session.Chunks)
+	resumedID := tracker.uploadID()
+	uploaded, err := o.uploadChunks(ctx, info, options.Workers, tracker)
+	if resumedID != "" && isClientError(err) {
+		// The server no longer accepts the upload ID, which is forgotten so
+		// that the next upload starts over
+		if delErr := tracker.finished(); delErr != nil {
+			return nil, delErr
+		}
+		return nil, fmt.Errorf("%w: %s: %v", UploadSessionExpired, o.Path, err)
+	}
+	return uploaded, err
+}
+
+// uploadChunk uploads a chunk of the file and records its checksum in info.
+// The buffer of the chunk is released to the pool of info in any case
+func (o *Object) uploadChunk(ctx context.Context, info *ChunkUploadInfo, uploadID string, chunk []byte, chunkNum int, last bool) (*UploadInfo, string, error) {
+	defer info.ReleaseChunk(chunk)
+	csum := SHA512Digest(chunk)
+	uploadInfo := &UploadInfo{
+		Path:      o.Path,
+		Data:      bytes.NewReader(chunk),
+		Csum:      csum,
+		ChunkNum:  chunkNum,
+		ChunkSize: int64(len(chunk)),
+		UploadID:  uploadID,
+	}
+	headers := map[string]string{
+		headerChunkNum:      strconv.Itoa(chunkNum),
+		headerChunkChecksum: csum,
+	}
+	if uploadID != "" {
+		headers[headerUploadID] = uploadID
+	}
+	if last {
+		headers[headerLastChunk] = "true"
+	}
+	resp, err := o.chunkUpload(ctx, uploadInfo, headers)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to upload chunk %d of %s: %w", chunkNum, o.Path, err)
+	}
+	info.SetChunkCheckSum(chunkNum, csum)
+	return uploadInfo, resp.Header.Get(headerChecksum), nil
+}
+
+// uploadChunks uploads the file in chunks read from info. The first chunk is
+// uploaded on its own to start the upload session and the last chunk once all
+// the others are uploaded to complete it. The progress is recorded by tracker
+// if it is not nil; a resumed upload has no first chunk to upload
+func (o *Object) uploadChunks(ctx context.Context, info *ChunkUploadInfo, workers int, tracker *sessionTracker) (*Object, error) {
+	// The last chunk is held by info until uploaded
+	defer func() {
+		if lastChunk, _ := info.GetLastChunk(); lastChunk != nil {
+			info.ReleaseChunk(lastChunk)
+		}
+	}()
+	uploadID := tracker.uploadID()
+	if uploadID == "" {
+		chunk, _, chunkNum, err := info.GetChunkContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		first, _, err := o.uploadChunk(ctx, info, "", chunk, chunkNum, false)
+		if err != nil {
+			return nil, err
+		}
+		uploadID = first.UploadID
+		err = tracker.completed(first, uploadID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var firstErr error
+	var errMutex sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				chunk, remaining, chunkNum, err := info.GetChunkContext(ctx)
+				if err == nil && (chunk == nil || remaining == 0) {
+					// Done, the last chunk is kept by info for later
+					return
+				}
+				var uploaded *UploadInfo
+				if err == nil {
+					uploaded, _, err = o.uploadChunk(ctx, info, uploadID, chunk, chunkNum, false)
+				}
+				if err == nil {
+					err = tracker.completed(uploaded, uploadID)
+				}
+				if err != nil {
+					errMutex.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMutex.Unlock()
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	lastChunk, lastChunkNum := info.GetLastChunk()
+	_, checksum, err := o.uploadChunk(ctx, info, uploadID, lastChunk, lastChunkNum, true)
+	if err != nil {
+		return nil, err
+	}
+	err = tracker.finished()
+	if err != nil {
+		return nil, err
+	}
+	expected := SHA512Digest([]byte(info.GetResultCsum()))
+	if checksum != "" && checksum != expected {
+		return nil, &IntegrityError{Path: o.Path, Expected: expected, Actual: checksum}
+	}
+	return &Object{
+		Client:   o.Client,
+		ModTime:  o.ModTime,
+		Checksum: checksum,
+		Path:     o.Path,
+		Size:     o.Size,
+	}, nil
+}
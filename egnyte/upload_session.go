@@ -0,0 +1,169 @@
+package egnyte
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var UploadSessionExpired = errors.New("upload session expired")
+
+// FileUploadStore is an UploadStateStore keeping every upload session in a
+// JSON file in a local directory
+type FileUploadStore struct {
+	dir string
+}
+
+// NewFileUploadStore returns a FileUploadStore keeping its files in dir,
+// which is created if needed
+func NewFileUploadStore(dir string) (*FileUploadStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	return &FileUploadStore{dir: dir}, nil
+}
+
+// file returns the path of the file holding the session of the upload to path
+func (s *FileUploadStore) file(path string) string {
+	return filepath.Join(s.dir, SHA512Digest([]byte(path))[:32]+".json")
+}
+
+func (s *FileUploadStore) Load(path string) (*UploadSession, error) {
+	data, err := ioutil.ReadFile(s.file(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var session *UploadSession
+	err = json.Unmarshal(data, &session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *FileUploadStore) Save(session *UploadSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so that a crash never leaves a partial
+	// session behind
+	tmpFile := s.file(session.Path) + ".tmp"
+	err = ioutil.WriteFile(tmpFile, data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, s.file(session.Path))
+}
+
+func (s *FileUploadStore) Delete(path string) error {
+	err := os.Remove(s.file(path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// isClientError checks if err is an Egnyte API error with a 4xx status code
+func isClientError(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
+
+// sessionTracker records the progress of a chunked upload in a store. All its
+// methods do nothing on a nil tracker
+type sessionTracker struct {
+	mutex    sync.Mutex
+	store    UploadStateStore
+	session  *UploadSession
+	chunkNum int // Chunk the upload starts or resumes at
+}
+
+// resumeSession loads the session of a previous upload to the object from
+// store. The chunks recorded as uploaded are read from the body of the object
+// and compared with their checksums, so that the upload is resumed only with
+// the same content, at the first chunk that was not uploaded or differs. It
+// returns the tracker and the reader of the data still to be uploaded
+func resumeSession(store UploadStateStore, obj *Object, chunkSize int64) (*sessionTracker, io.Reader, error) {
+	size := int64(obj.Size)
+	session, err := store.Load(obj.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	tracker := &sessionTracker{store: store, chunkNum: 1}
+	if session == nil || session.UploadID == "" || session.Size != size || session.ChunkSize != chunkSize {
+		tracker.session = &UploadSession{
+			Path:      obj.Path,
+			Size:      size,
+			ChunkSize: chunkSize,
+			ModTime:   obj.ModTime,
+			Chunks:    map[int]string{},
+		}
+		return tracker, obj.Body, nil
+	}
+
+	// The last chunk is always uploaded again as the session is deleted as
+	// soon as it is uploaded
+	lastChunkNum := int((size + chunkSize - 1) / chunkSize)
+	checksums := map[int]string{}
+	buf := make([]byte, chunkSize)
+	var unread []byte
+	for ; tracker.chunkNum < lastChunkNum; tracker.chunkNum++ {
+		_, err = io.ReadFull(obj.Body, buf)
+		if err != nil {
+			return nil, nil, err
+		}
+		csum := session.Chunks[tracker.chunkNum]
+		if csum == "" || csum != SHA512Digest(buf) {
+			unread = buf
+			break
+		}
+		checksums[tracker.chunkNum] = csum
+	}
+	reader := io.MultiReader(bytes.NewReader(unread), obj.Body)
+	if tracker.chunkNum == 1 {
+		// Not even the first chunk matches, this is a new upload
+		session.UploadID = ""
+	}
+	session.Chunks = checksums
+	session.ModTime = obj.ModTime
+	tracker.session = session
+	return tracker, reader, nil
+}
+
+// uploadID returns the upload ID of a resumed session
+func (t *sessionTracker) uploadID() string {
+	if t == nil {
+		return ""
+	}
+	return t.session.UploadID
+}
+
+// completed records an uploaded chunk
+func (t *sessionTracker) completed(chunk *UploadInfo, uploadID string) error {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.session.UploadID = uploadID
+	t.session.Chunks[chunk.ChunkNum] = chunk.Csum
+	return t.store.Save(t.session)
+}
+
+// finished deletes the session of a completed upload
+func (t *sessionTracker) finished() error {
+	if t == nil {
+		return nil
+	}
+	return t.store.Delete(t.session.Path)
+}
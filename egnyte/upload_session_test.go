@@ -0,0 +1,134 @@
+package egnyte
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// failingChunk fails the upload of chunk failAt once
+type failingChunk struct {
+	*fakeChunkedUpload
+	failAt   int
+	received []int
+}
+
+func (f *failingChunk) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	chunkNum, _ := strconv.Atoi(r.Header.Get(headerChunkNum))
+	f.received = append(f.received, chunkNum)
+	if chunkNum == f.failAt {
+		f.failAt = 0
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	f.fakeChunkedUpload.ServeHTTP(w, r)
+}
+
+// Test an interrupted upload is resumed from the first chunk not uploaded
+func TestUploadResume(t *testing.T) {
+	server := &failingChunk{
+		fakeChunkedUpload: &fakeChunkedUpload{t: t, chunks: map[int][]byte{}, checksums: map[int]string{}},
+		failAt:            5,
+	}
+	client := newTestClient(t, server)
+	store, err := NewFileUploadStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	content := bytes.Repeat([]byte("0123456789"), 800)
+	upOpts := &UploadOptions{ChunkThreshold: 1000, ChunkSize: 1000, Workers: 1, StateStore: store}
+
+	_, err = client.Upload(context.Background(), "/Shared/big.txt", bytes.NewReader(content), int64(len(content)), upOpts)
+	if err == nil {
+		t.Fatalf("upload did not fail")
+	}
+	session, err := store.Load("/Shared/big.txt")
+	if err != nil || session == nil || len(session.Chunks) != 4 || session.UploadID != "upload-1" {
+		t.Fatalf("%+v, %v", session, err)
+	}
+
+	server.received = nil
+	_, err = client.Upload(context.Background(), "/Shared/big.txt", bytes.NewReader(content), int64(len(content)), upOpts)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(server.received) != 4 || server.received[0] != 5 {
+		t.Errorf("uploaded chunks %v", server.received)
+	}
+	if !bytes.Equal(server.content(), content) {
+		t.Errorf("uploaded content differs")
+	}
+	if session, _ := store.Load("/Shared/big.txt"); session != nil {
+		t.Errorf("session not deleted: %+v", session)
+	}
+}
+
+// expiredSession rejects the upload ID of a stale session
+type expiredSession struct {
+	*fakeChunkedUpload
+	rejected int
+}
+
+func (f *expiredSession) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		_ = json.NewEncoder(w).Encode(&Object{Checksum: SHA512Digest(f.content()), LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT"})
+		return
+	}
+	if r.Header.Get(headerUploadID) == "stale" {
+		f.rejected++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessage": "Invalid upload id"}`))
+		return
+	}
+	f.fakeChunkedUpload.ServeHTTP(w, r)
+}
+
+// Test a resumed upload whose session expired restarts from the first chunk
+func TestUploadResumeExpired(t *testing.T) {
+	server := &expiredSession{
+		fakeChunkedUpload: &fakeChunkedUpload{t: t, chunks: map[int][]byte{}, checksums: map[int]string{}},
+	}
+	client := newTestClient(t, server)
+	store, err := NewFileUploadStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	content := bytes.Repeat([]byte("0123456789"), 800)
+	stale := func() {
+		err := store.Save(&UploadSession{
+			Path:      "/Shared/big.txt",
+			UploadID:  "stale",
+			Size:      int64(len(content)),
+			ChunkSize: 1000,
+			Chunks:    map[int]string{1: SHA512Digest(content[:1000])},
+		})
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+	upOpts := &UploadOptions{ChunkThreshold: 1000, ChunkSize: 1000, Workers: 1, StateStore: store, VerifyChecksum: true}
+
+	// A reader that cannot be rewound gets an error and a clean slate
+	stale()
+	_, err = client.Upload(context.Background(), "/Shared/big.txt", io.MultiReader(bytes.NewReader(content)), int64(len(content)), upOpts)
+	if !errors.Is(err, UploadSessionExpired) {
+		t.Fatalf("%v", err)
+	}
+	if session, _ := store.Load("/Shared/big.txt"); session != nil {
+		t.Errorf("session not deleted: %+v", session)
+	}
+
+	stale()
+	_, err = client.Upload(context.Background(), "/Shared/big.txt", bytes.NewReader(content), int64(len(content)), upOpts)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if server.rejected != 2 || !bytes.Equal(server.content(), content) {
+		t.Errorf("%d rejected", server.rejected)
+	}
+}
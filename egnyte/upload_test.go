@@ -0,0 +1,141 @@
+package egnyte
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeChunkedUpload accepts chunked uploads, checking the chunk headers, and
+// single request uploads
+type fakeChunkedUpload struct {
+	t         *testing.T
+	mutex     sync.Mutex
+	chunks    map[int][]byte
+	checksums map[int]string
+	single    []byte
+	lastSeen  bool
+}
+
+func (f *fakeChunkedUpload) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	if strings.HasPrefix(r.URL.Path, "/pubapi/v1/fs-content/") {
+		f.single = body
+		w.Header().Set(headerChecksum, SHA512Digest(body))
+		return
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	chunkNum, _ := strconv.Atoi(r.Header.Get(headerChunkNum))
+	if chunkNum != 1 && r.Header.Get(headerUploadID) != "upload-1" {
+		f.t.Errorf("chunk %d uploaded without upload id", chunkNum)
+	}
+	if f.lastSeen {
+		f.t.Errorf("chunk %d uploaded after the last chunk", chunkNum)
+	}
+	if r.Header.Get(headerChunkChecksum) != SHA512Digest(body) {
+		f.t.Errorf("wrong checksum for chunk %d", chunkNum)
+	}
+	f.chunks[chunkNum] = body
+	f.checksums[chunkNum] = r.Header.Get(headerChunkChecksum)
+	w.Header().Set(headerUploadID, "upload-1")
+	if r.Header.Get(headerLastChunk) == "true" {
+		f.lastSeen = true
+		nums := []int{}
+		for num := range f.checksums {
+			nums = append(nums, num)
+		}
+		sort.Ints(nums)
+		hash := sha512.New()
+		for _, num := range nums {
+			hash.Write([]byte(f.checksums[num]))
+		}
+		w.Header().Set(headerChecksum, hex.EncodeToString(hash.Sum(nil)))
+	}
+}
+
+// content returns the uploaded chunks joined in order
+func (f *fakeChunkedUpload) content() []byte {
+	content := []byte{}
+	for num := 1; num <= len(f.chunks); num++ {
+		content = append(content, f.chunks[num]...)
+	}
+	return content
+}
+
+// Test Upload uploads large files in chunks and small files in one request
+func TestUpload(t *testing.T) {
+	server := &fakeChunkedUpload{t: t, chunks: map[int][]byte{}, checksums: map[int]string{}}
+	client := newTestClient(t, server)
+	content := bytes.Repeat([]byte("0123456789"), 1001)
+	upOpts := &UploadOptions{ChunkThreshold: 2000, ChunkSize: 1000, Workers: 3}
+	obj, err := client.Upload(context.Background(), "/Shared/big.txt", bytes.NewReader(content), int64(len(content)), upOpts)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(server.chunks) != 11 || !server.lastSeen || !bytes.Equal(server.content(), content) {
+		t.Errorf("%d chunks", len(server.chunks))
+	}
+	if obj.Path != "/Shared/big.txt" || obj.Checksum == "" {
+		t.Errorf("%+v", obj)
+	}
+
+	upOpts.VerifyChecksum = true
+	_, err = client.Upload(context.Background(), "/Shared/small.txt", bytes.NewReader(content[:1500]), 1500, upOpts)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !bytes.Equal(server.single, content[:1500]) {
+		t.Errorf("%d bytes uploaded", len(server.single))
+	}
+}
+
+// Test Upload does not wait for a buffer once the last chunk was read, when
+// the pool budget only fits a chunk or two
+func TestUploadSmallBudget(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1001)
+	upload := func(client *Client, pool *BufferPool) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		upOpts := &UploadOptions{ChunkThreshold: 2000, ChunkSize: 1000, Workers: 3, BufferPool: pool}
+		_, err := client.Upload(ctx, "/Shared/big.txt", bytes.NewReader(content), int64(len(content)), upOpts)
+		return err
+	}
+
+	server := &fakeChunkedUpload{t: t, chunks: map[int][]byte{}, checksums: map[int]string{}}
+	if err := upload(newTestClient(t, server), NewBufferPool(1000)); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !bytes.Equal(server.content(), content) {
+		t.Errorf("%d chunks", len(server.chunks))
+	}
+
+	pool := NewBufferPool(2000)
+	servers := []*fakeChunkedUpload{}
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		server := &fakeChunkedUpload{t: t, chunks: map[int][]byte{}, checksums: map[int]string{}}
+		servers = append(servers, server)
+		client := newTestClient(t, server)
+		go func() { errs <- upload(client, pool) }()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("%s", err)
+		}
+	}
+	for i, server := range servers {
+		if !bytes.Equal(server.content(), content) {
+			t.Errorf("upload %d: %d chunks", i, len(server.chunks))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package egnyte
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+var VersionNotFound = errors.New("version not found")
+
+// newFileVersion returns the FileVersion of a file or version object
+func newFileVersion(obj *Object, current bool) *FileVersion {
+	return &FileVersion{
+		EntryID:    obj.EntryID,
+		Size:       int64(obj.Size),
+		UploadedBy: obj.UploadedBy,
+		ModTime:    obj.ModTime,
+		Checksum:   obj.Checksum,
+		Current:    current,
+	}
+}
+
+// ListVersions lists all the versions of the file, newest first
+func (o *Object) ListVersions(ctx context.Context) ([]*FileVersion, error) {
+	file, err := o.stat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if file.IsFolder {
+		return nil, FileRequired
+	}
+	versions := []*FileVersion{newFileVersion(file, true)}
+	for _, version := range file.Versions {
+		if version.EntryID != file.EntryID {
+			versions = append(versions, newFileVersion(version, false))
+		}
+	}
+	sort.SliceStable(versions[1:], func(i, j int) bool {
+		return versions[i+1].ModTime.After(versions[j+1].ModTime)
+	})
+	return versions, nil
+}
+
+// version returns the object for the version of the file with entryID
+func (o *Object) version(entryID string) *Object {
+	return &Object{
+		Client:  o.Client,
+		Path:    o.Path,
+		EntryID: entryID,
+	}
+}
+
+// GetVersion downloads the version of the file with entryID
+func (o *Object) GetVersion(ctx context.Context, entryID string) (io.ReadCloser, error) {
+	return o.version(entryID).Get(ctx)
+}
+
+// DeleteVersion deletes the version of the file with entryID, leaving the
+// other versions in place
+func (o *Object) DeleteVersion(ctx context.Context, entryID string) error {
+	return o.version(entryID).Delete(ctx)
+}
+
+// PromoteVersion makes the version of the file with entryID the current one by
+// uploading its content as a new version, which is returned
+func (o *Object) PromoteVersion(ctx context.Context, entryID string) (*Object, error) {
+	versions, err := o.ListVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var promoted *FileVersion
+	for _, version := range versions {
+		if version.EntryID == entryID {
+			promoted = version
+		}
+	}
+	if promoted == nil {
+		return nil, fmt.Errorf("%w: %s of %s", VersionNotFound, entryID, o.Path)
+	}
+	if promoted.Current {
+		return o.stat(ctx)
+	}
+	body, err := o.GetVersion(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return o.Client.Upload(ctx, o.Path, body, promoted.Size, &UploadOptions{
+		ModTime:        time.Now(),
+		VerifyChecksum: true,
+	})
+}
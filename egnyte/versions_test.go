@@ -0,0 +1,73 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeVersions serves a file with three versions, their content being their
+// entry IDs, and records uploads
+type fakeVersions struct {
+	uploaded []byte
+}
+
+func (f *fakeVersions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "POST":
+		f.uploaded, _ = ioutil.ReadAll(r.Body)
+		w.Header().Set(headerChecksum, SHA512Digest(f.uploaded))
+	case strings.HasPrefix(r.URL.Path, "/pubapi/v1/fs-content/"):
+		entryID := r.URL.Query().Get("entry_id")
+		if entryID == "" {
+			entryID = "v3"
+		}
+		_, _ = w.Write([]byte(entryID))
+	default:
+		version := func(entryID, modTime string) *Object {
+			return &Object{EntryID: entryID, Size: 2, Checksum: SHA512Digest([]byte(entryID)), LastModifiedStr: modTime}
+		}
+		file := version("v3", "Wed, 03 Jan 2024 10:00:00 GMT")
+		file.Path = "/Shared/doc.txt"
+		file.Versions = []*Object{
+			version("v1", "Mon, 01 Jan 2024 10:00:00 GMT"),
+			version("v3", "Wed, 03 Jan 2024 10:00:00 GMT"),
+			version("v2", "Tue, 02 Jan 2024 10:00:00 GMT"),
+		}
+		_ = json.NewEncoder(w).Encode(file)
+	}
+}
+
+// Test ListVersions and PromoteVersion
+func TestVersions(t *testing.T) {
+	server := &fakeVersions{}
+	client := newTestClient(t, server)
+	file := client.Object("/Shared/doc.txt")
+	versions, err := file.ListVersions(context.Background())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	entryIDs := []string{}
+	for _, version := range versions {
+		entryIDs = append(entryIDs, version.EntryID)
+	}
+	if strings.Join(entryIDs, ",") != "v3,v2,v1" || !versions[0].Current || versions[1].Current {
+		t.Errorf("%v", entryIDs)
+	}
+
+	_, err = file.PromoteVersion(context.Background(), "v1")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if string(server.uploaded) != "v1" {
+		t.Errorf("uploaded %q", server.uploaded)
+	}
+	_, err = file.PromoteVersion(context.Background(), "v9")
+	if !errors.Is(err, VersionNotFound) {
+		t.Errorf("%v", err)
+	}
+}
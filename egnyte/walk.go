@@ -0,0 +1,142 @@
+package egnyte
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"sync"
+)
+
+// SkipDir can be returned by a WalkFunc to skip the folder it was called for,
+// or the rest of the parent folder, subfolders included, when returned for a
+// file
+var SkipDir = fs.SkipDir
+
+// SkipAll can be returned by a WalkFunc to stop the walk without an error
+var SkipAll = errors.New("skip everything and stop the walk")
+
+// defaultWalkWorkers is the number of folders listed concurrently by Walk
+// when WalkOptions.Workers is not set
+const defaultWalkWorkers = 4
+
+// WalkFunc is called by Walk for every file and folder in the tree. If listing
+// a folder fails, it is called once more for that folder with the error and
+// the walk goes on unless the function returns an error itself
+type WalkFunc func(path string, obj *Object, err error) error
+
+// WalkOptions are the optional settings for Walk
+type WalkOptions struct {
+	Workers  int      // Number of folders listed concurrently, defaults to 4
+	MaxDepth int      // Depth below root to descend to, unlimited if zero
+	Include  []string // Only files with a name matching one of these path.Match patterns are visited
+	Exclude  []string // Files and folders with a name matching one of these patterns are skipped
+}
+
+// matchAny checks if name matches one of the path.Match patterns
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk walks the folder tree rooted at root calling fn for root and every file
+// and folder below it, similar to filepath.WalkDir. Folders are listed by
+// concurrent workers, so the order of the calls is not deterministic, but fn
+// is never called concurrently. Walk returns the error of ctx if it is done
+// before the walk completes
+func Walk(ctx context.Context, root *Object, fn WalkFunc, walkOpts *WalkOptions) error {
+	if walkOpts == nil {
+		walkOpts = &WalkOptions{}
+	}
+	workers := walkOpts.Workers
+	if workers <= 0 {
+		workers = defaultWalkWorkers
+	}
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var walkErr error
+	var fnMutex sync.Mutex
+	var wg sync.WaitGroup
+	listing := make(chan struct{}, workers)
+
+	// call calls fn and records whether the walk has to stop
+	stopped := false
+	call := func(p string, obj *Object, err error) error {
+		fnMutex.Lock()
+		defer fnMutex.Unlock()
+		if stopped || ctx.Err() != nil {
+			return SkipAll
+		}
+		err = fn(p, obj, err)
+		if err != nil && err != SkipDir {
+			stopped = true
+			if err != SkipAll {
+				walkErr = err
+			}
+			cancel()
+		}
+		return err
+	}
+
+	var visit func(folder *Object, depth int)
+	visit = func(folder *Object, depth int) {
+		defer wg.Done()
+		listing <- struct{}{}
+		list, err := folder.List(ctx)
+		<-listing
+		if err != nil {
+			if ctx.Err() == nil {
+				_ = call(folder.Path, folder, err)
+			}
+			return
+		}
+		if depth == 0 {
+			if call(list.Path, list, nil) != nil {
+				return
+			}
+		}
+		for _, file := range list.Files {
+			if matchAny(walkOpts.Exclude, file.Name) {
+				continue
+			}
+			if len(walkOpts.Include) > 0 && !matchAny(walkOpts.Include, file.Name) {
+				continue
+			}
+			// As with filepath.WalkDir, SkipDir for a file skips the rest of
+			// its folder, subfolders included
+			if call(file.Path, file, nil) != nil {
+				return
+			}
+		}
+		for _, subFolder := range list.Folders {
+			if matchAny(walkOpts.Exclude, subFolder.Name) {
+				continue
+			}
+			err := call(subFolder.Path, subFolder, nil)
+			if err == SkipDir {
+				continue
+			}
+			if err != nil {
+				return
+			}
+			if walkOpts.MaxDepth == 0 || depth+1 < walkOpts.MaxDepth {
+				wg.Add(1)
+				go visit(subFolder, depth+1)
+			}
+		}
+	}
+
+	wg.Add(1)
+	visit(root, 0)
+	wg.Wait()
+	if walkErr == nil {
+		return parent.Err()
+	}
+	return walkErr
+}
@@ -0,0 +1,218 @@
+package egnyte
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// fakeFolders serves folder listings and file downloads for a fake folder
+// tree. Keys are folder paths and values the names of their children, folders
+// ending with a slash. The content of a file is its name. Listing a folder
+// named "denied" fails with 403
+type fakeFolders map[string][]string
+
+// file returns the fake file object at p
+func (f fakeFolders) file(p string) *Object {
+	for _, child := range f[path.Dir(p)] {
+		if child == path.Base(p) {
+			return &Object{
+				Path:            p,
+				Name:            child,
+				Size:            len(child),
+				NumVersions:     1,
+				LastModifiedStr: "Mon, 02 Jan 2006 15:04:05 GMT",
+			}
+		}
+	}
+	return nil
+}
+
+func (f fakeFolders) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/pubapi/v1/fs-content/") {
+		file := f.file(strings.TrimPrefix(r.URL.Path, "/pubapi/v1/fs-content"))
+		if file == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(file.Name))
+		return
+	}
+	p := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/pubapi/v1/fs"), "/")
+	if path.Base(p) == "denied" {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errorMessage": "Access denied"}`))
+		return
+	}
+	children, ok := f[p]
+	if !ok {
+		if file := f.file(p); file != nil {
+			_ = json.NewEncoder(w).Encode(file)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	list := &Object{Path: p, Name: path.Base(p), IsFolder: true, TotalCount: len(children)}
+	for _, child := range children {
+		if strings.HasSuffix(child, "/") {
+			name := strings.TrimSuffix(child, "/")
+			list.Folders = append(list.Folders, &Object{Path: path.Join(p, name), Name: name, IsFolder: true})
+		} else {
+			list.Files = append(list.Files, f.file(path.Join(p, child)))
+		}
+	}
+	_ = json.NewEncoder(w).Encode(list)
+}
+
+var testTree = fakeFolders{
+	"/Shared/root":           {"a/", "b/", "denied/", "top.txt", "skip.log"},
+	"/Shared/root/a":         {"a1.txt", "a2.txt", "deep/"},
+	"/Shared/root/a/deep":    {"d.txt"},
+	"/Shared/root/b":         {"b1.txt"},
+	"/Shared/root/b/ignored": {"never.txt"},
+}
+
+// Test Walk visits the tree and continues past folders that cannot be listed
+func TestWalk(t *testing.T) {
+	client := newTestClient(t, testTree)
+	visited := []string{}
+	err := Walk(context.Background(), client.Object("/Shared/root"), func(p string, obj *Object, err error) error {
+		if err != nil {
+			if !isStatus(err, http.StatusForbidden) {
+				t.Errorf("%s: %s", p, err)
+			}
+			return nil
+		}
+		if obj.Name == "b" {
+			return SkipDir
+		}
+		visited = append(visited, p)
+		return nil
+	}, &WalkOptions{Workers: 2, Exclude: []string{"*.log"}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	sort.Strings(visited)
+	expected := []string{
+		"/Shared/root",
+		"/Shared/root/a",
+		"/Shared/root/a/a1.txt",
+		"/Shared/root/a/a2.txt",
+		"/Shared/root/a/deep",
+		"/Shared/root/a/deep/d.txt",
+		"/Shared/root/denied",
+		"/Shared/root/top.txt",
+	}
+	if strings.Join(visited, ",") != strings.Join(expected, ",") {
+		t.Errorf("%v", visited)
+	}
+}
+
+// Test Walk honours MaxDepth and SkipAll
+func TestWalkDepthAndSkipAll(t *testing.T) {
+	client := newTestClient(t, testTree)
+	count := 0
+	err := Walk(context.Background(), client.Object("/Shared/root"), func(p string, obj *Object, err error) error {
+		if err != nil {
+			return nil
+		}
+		if strings.Contains(p, "/a/") {
+			t.Errorf("walked below max depth: %s", p)
+		}
+		count++
+		return nil
+	}, &WalkOptions{MaxDepth: 1})
+	if err != nil || count != 6 {
+		t.Errorf("%d entries, %v", count, err)
+	}
+
+	count = 0
+	err = Walk(context.Background(), client.Object("/Shared/root"), func(p string, obj *Object, err error) error {
+		count++
+		return SkipAll
+	}, nil)
+	if err != nil || count != 1 {
+		t.Errorf("%d entries, %v", count, err)
+	}
+}
+
+// Test SkipDir returned for a file skips the rest of its folder
+func TestWalkSkipDirFile(t *testing.T) {
+	client := newTestClient(t, testTree)
+	visited := []string{}
+	err := Walk(context.Background(), client.Object("/Shared/root"), func(p string, obj *Object, err error) error {
+		if err != nil {
+			return nil
+		}
+		visited = append(visited, p)
+		if p == "/Shared/root/top.txt" {
+			return SkipDir
+		}
+		return nil
+	}, &WalkOptions{Exclude: []string{"*.log"}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if strings.Join(visited, ",") != "/Shared/root,/Shared/root/top.txt" {
+		t.Errorf("%v", visited)
+	}
+}
+
+// Test Walk stops and returns the error of the context once it is done
+func TestWalkCanceled(t *testing.T) {
+	client := newTestClient(t, testTree)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	count := 0
+	err := Walk(ctx, client.Object("/Shared/root"), func(p string, obj *Object, err error) error {
+		count++
+		return nil
+	}, nil)
+	if err != context.Canceled || count != 0 {
+		t.Errorf("%d entries, %v", count, err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	err = Walk(ctx, client.Object("/Shared/root"), func(p string, obj *Object, err error) error {
+		if p == "/Shared/root/a" {
+			cancel()
+		}
+		if strings.HasPrefix(p, "/Shared/root/a/") {
+			t.Errorf("walked %s after cancel", p)
+		}
+		return nil
+	}, &WalkOptions{Workers: 1})
+	if err != context.Canceled {
+		t.Errorf("%v", err)
+	}
+}
+
+// Test Stats falls back to walking the tree when the stats API is missing and
+// finds out by itself that the object is a folder
+func TestStatsFallback(t *testing.T) {
+	tree := fakeFolders{}
+	for k, v := range testTree {
+		if !strings.Contains(k, "denied") {
+			tree[k] = v
+		}
+	}
+	tree["/Shared/root"] = []string{"a/", "b/", "top.txt"}
+	client := newTestClient(t, tree)
+	stats, err := client.Object("/Shared/root").Stats(context.Background())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if stats.Folders != 3 || stats.Files != 5 || stats.Versions != 5 {
+		t.Errorf("%+v", stats)
+	}
+	_, err = client.Object("/Shared/root/top.txt").Stats(context.Background())
+	if err != FolderRequired {
+		t.Errorf("%v", err)
+	}
+}